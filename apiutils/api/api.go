@@ -3,7 +3,11 @@
 
 package api
 
-import "time"
+import (
+	"maps"
+	"slices"
+	"time"
+)
 
 type Metadata struct {
 	ID          string            `json:"id"`
@@ -15,7 +19,28 @@ type Metadata struct {
 	Generation      int64      `json:"generation"`
 	ResourceVersion uint64     `json:"resourceVersion"`
 
+	// Sequence is the store-wide sequence number of the last write of the object.
+	Sequence uint64 `json:"sequence,omitempty"`
+
+	// ObservedGeneration is the generation the object was last reconciled at.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	Finalizers []string `json:"finalizers,omitempty"`
+
+	// TTL, if set, is the duration after CreatedAt the object is deleted by stores supporting expiry.
+	TTL time.Duration `json:"ttl,omitempty"`
+}
+
+// DeepCopyInto copies m into out, so objects embedding Metadata can implement a typed DeepCopy.
+func (m *Metadata) DeepCopyInto(out *Metadata) {
+	*out = *m
+	out.Annotations = maps.Clone(m.Annotations)
+	out.Labels = maps.Clone(m.Labels)
+	if m.DeletedAt != nil {
+		deletedAt := *m.DeletedAt
+		out.DeletedAt = &deletedAt
+	}
+	out.Finalizers = slices.Clone(m.Finalizers)
 }
 
 func (m *Metadata) GetID() string {
@@ -42,6 +67,10 @@ func (m *Metadata) GetGeneration() int64 {
 	return m.Generation
 }
 
+func (m *Metadata) GetObservedGeneration() int64 {
+	return m.ObservedGeneration
+}
+
 func (m *Metadata) GetFinalizers() []string {
 	return m.Finalizers
 }
@@ -50,6 +79,14 @@ func (m *Metadata) GetResourceVersion() uint64 {
 	return m.ResourceVersion
 }
 
+func (m *Metadata) GetSequence() uint64 {
+	return m.Sequence
+}
+
+func (m *Metadata) GetTTL() time.Duration {
+	return m.TTL
+}
+
 func (m *Metadata) SetID(id string) {
 	m.ID = id
 }
@@ -74,10 +111,26 @@ func (m *Metadata) SetGeneration(generation int64) {
 	m.Generation = generation
 }
 
+func (m *Metadata) SetObservedGeneration(observedGeneration int64) {
+	m.ObservedGeneration = observedGeneration
+}
+
 func (m *Metadata) SetFinalizers(finalizers []string) {
 	m.Finalizers = finalizers
 }
 
+func (m *Metadata) SetTTL(ttl time.Duration) {
+	m.TTL = ttl
+}
+
+func (m *Metadata) SetResourceVersion(resourceVersion uint64) {
+	m.ResourceVersion = resourceVersion
+}
+
+func (m *Metadata) SetSequence(sequence uint64) {
+	m.Sequence = sequence
+}
+
 func (m *Metadata) IncrementResourceVersion() {
 	m.ResourceVersion++
 }
@@ -89,8 +142,11 @@ type Object interface {
 	GetCreatedAt() time.Time
 	GetDeletedAt() *time.Time
 	GetGeneration() int64
+	GetObservedGeneration() int64
 	GetFinalizers() []string
 	GetResourceVersion() uint64
+	GetSequence() uint64
+	GetTTL() time.Duration
 
 	SetID(id string)
 	SetAnnotations(annotations map[string]string)
@@ -98,6 +154,10 @@ type Object interface {
 	SetCreatedAt(createdAt time.Time)
 	SetDeletedAt(deleted *time.Time)
 	SetGeneration(generation int64)
+	SetObservedGeneration(observedGeneration int64)
 	SetFinalizers(finalizers []string)
+	SetTTL(ttl time.Duration)
+	SetResourceVersion(resourceVersion uint64)
+	SetSequence(sequence uint64)
 	IncrementResourceVersion()
 }
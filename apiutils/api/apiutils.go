@@ -9,6 +9,14 @@ import (
 	"slices"
 
 	"github.com/ironcore-dev/controller-utils/metautils"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// LabelsAnnotation is the canonical annotation key labels are stored at.
+	LabelsAnnotation = "provider-utils.ironcore.dev/labels"
+	// AnnotationsAnnotation is the canonical annotation key annotations are stored at.
+	AnnotationsAnnotation = "provider-utils.ironcore.dev/annotations"
 )
 
 func Zero[E any]() E {
@@ -71,3 +79,53 @@ func GetAnnotationsAnnotation(o Metadata, key string) (map[string]string, error)
 
 	return annotations, nil
 }
+
+// SetLabels stores the labels at LabelsAnnotation.
+func SetLabels(o Object, labels map[string]string) error {
+	return SetLabelsAnnotation(o, LabelsAnnotation, labels)
+}
+
+// GetLabels returns the labels stored at LabelsAnnotation.
+func GetLabels(m Metadata) (map[string]string, error) {
+	return GetLabelsAnnotation(m, LabelsAnnotation)
+}
+
+// SetAnnotations stores the annotations at AnnotationsAnnotation.
+func SetAnnotations(o Object, annotations map[string]string) error {
+	return SetAnnotationsAnnotation(o, AnnotationsAnnotation, annotations)
+}
+
+// GetAnnotations returns the annotations stored at AnnotationsAnnotation.
+func GetAnnotations(m Metadata) (map[string]string, error) {
+	return GetAnnotationsAnnotation(m, AnnotationsAnnotation)
+}
+
+// MatchesSelector reports whether the labels stored at LabelsAnnotation match the selector.
+// Metadata without labels is matched as having no labels.
+func MatchesSelector(m Metadata, selector labels.Selector) (bool, error) {
+	if _, ok := m.GetAnnotations()[LabelsAnnotation]; !ok {
+		return selector.Matches(labels.Set{}), nil
+	}
+
+	objLabels, err := GetLabels(m)
+	if err != nil {
+		return false, fmt.Errorf("error decoding labels: %w", err)
+	}
+
+	return selector.Matches(labels.Set(objLabels)), nil
+}
+
+// BumpGeneration increments the generation of the object, e.g. after its spec changed.
+func BumpGeneration(o Object) {
+	o.SetGeneration(o.GetGeneration() + 1)
+}
+
+// MarkObserved records the current generation of the object as reconciled.
+func MarkObserved(o Object) {
+	o.SetObservedGeneration(o.GetGeneration())
+}
+
+// NeedsReconcile reports whether the generation of the object has not been reconciled yet.
+func NeedsReconcile(m Metadata) bool {
+	return m.ObservedGeneration < m.Generation
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestSetLabels(t *testing.T) {
+	m := &api.Metadata{}
+	labels := map[string]string{"key": "value"}
+
+	if err := api.SetLabels(m, labels); err != nil {
+		t.Fatalf("SetLabels: %v", err)
+	}
+	if _, ok := m.Annotations[api.LabelsAnnotation]; !ok {
+		t.Fatalf("expected labels at %s, got %v", api.LabelsAnnotation, m.Annotations)
+	}
+
+	got, err := api.GetLabels(*m)
+	if err != nil {
+		t.Fatalf("GetLabels: %v", err)
+	}
+	if !maps.Equal(got, labels) {
+		t.Fatalf("expected labels %v, got %v", labels, got)
+	}
+}
+
+func TestSetAnnotations(t *testing.T) {
+	m := &api.Metadata{}
+	annotations := map[string]string{"key": "value"}
+
+	if err := api.SetAnnotations(m, annotations); err != nil {
+		t.Fatalf("SetAnnotations: %v", err)
+	}
+	if _, ok := m.Annotations[api.AnnotationsAnnotation]; !ok {
+		t.Fatalf("expected annotations at %s, got %v", api.AnnotationsAnnotation, m.Annotations)
+	}
+
+	got, err := api.GetAnnotations(*m)
+	if err != nil {
+		t.Fatalf("GetAnnotations: %v", err)
+	}
+	if !maps.Equal(got, annotations) {
+		t.Fatalf("expected annotations %v, got %v", annotations, got)
+	}
+}
+
+func TestMatchesSelector(t *testing.T) {
+	m := api.Metadata{
+		ID: "test-id-1234",
+		Annotations: map[string]string{
+			api.LabelsAnnotation: "{" +
+				"\"downward-api.machinepoollet.ironcore.dev/root-machine-namespace\":\"default\"," +
+				" \"downward-api.machinepoollet.ironcore.dev/root-machine-name\":\"machine1\"}",
+		},
+	}
+
+	for _, tc := range []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "downward-api.machinepoollet.ironcore.dev/root-machine-namespace=default", want: true},
+		{selector: "downward-api.machinepoollet.ironcore.dev/root-machine-namespace=other", want: false},
+		{selector: "downward-api.machinepoollet.ironcore.dev/root-machine-name in (machine1,machine2)", want: true},
+		{selector: "downward-api.machinepoollet.ironcore.dev/root-machine-name notin (machine1)", want: false},
+		{selector: "downward-api.machinepoollet.ironcore.dev/root-machine-name!=machine2", want: true},
+		{selector: "!tier", want: true},
+		{selector: "tier", want: false},
+	} {
+		selector, err := labels.Parse(tc.selector)
+		if err != nil {
+			t.Fatalf("Parse %q: %v", tc.selector, err)
+		}
+
+		got, err := api.MatchesSelector(m, selector)
+		if err != nil {
+			t.Fatalf("MatchesSelector %q: %v", tc.selector, err)
+		}
+		if got != tc.want {
+			t.Fatalf("expected %q to match %t, got %t", tc.selector, tc.want, got)
+		}
+	}
+
+	noLabels, err := api.MatchesSelector(api.Metadata{}, labels.SelectorFromSet(labels.Set{"tier": "db"}))
+	if err != nil || noLabels {
+		t.Fatalf("expected metadata without labels not to match, got %t (err: %v)", noLabels, err)
+	}
+
+	invalid := api.Metadata{Annotations: map[string]string{api.LabelsAnnotation: "{"}}
+	if _, err := api.MatchesSelector(invalid, labels.Everything()); err == nil {
+		t.Fatal("expected error on invalid labels annotation")
+	}
+}
+
+func TestNeedsReconcile(t *testing.T) {
+	obj := &api.Metadata{ID: "obj"}
+	if api.NeedsReconcile(*obj) {
+		t.Fatal("expected new object not to need a reconcile")
+	}
+
+	api.BumpGeneration(obj)
+	if obj.Generation != 1 {
+		t.Fatalf("expected generation 1, got %d", obj.Generation)
+	}
+	if !api.NeedsReconcile(*obj) {
+		t.Fatal("expected bumped object to need a reconcile")
+	}
+
+	api.MarkObserved(obj)
+	if obj.ObservedGeneration != 1 {
+		t.Fatalf("expected observed generation 1, got %d", obj.ObservedGeneration)
+	}
+	if api.NeedsReconcile(*obj) {
+		t.Fatal("expected observed object not to need a reconcile")
+	}
+}
+
+func TestDeepCopyInto(t *testing.T) {
+	deletedAt := time.Now()
+	m := api.Metadata{
+		ID:         "obj",
+		Labels:     map[string]string{"key": "value"},
+		DeletedAt:  &deletedAt,
+		Finalizers: []string{"finalizer"},
+	}
+
+	var out api.Metadata
+	m.DeepCopyInto(&out)
+	out.Labels["key"] = "mutated"
+	out.Finalizers[0] = "mutated"
+	*out.DeletedAt = time.Time{}
+
+	if m.Labels["key"] != "value" || m.Finalizers[0] != "finalizer" || !m.DeletedAt.Equal(deletedAt) {
+		t.Fatalf("expected mutation of the copy to not affect the original, got %+v", m)
+	}
+}
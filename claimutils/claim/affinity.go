@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// AntiAffinityPlugin is implemented by plugins that can avoid co-locating a claim with other claims.
+type AntiAffinityPlugin interface {
+	Plugin
+	// ClaimAvoiding claims like Claim, preferring devices not co-located with the devices of the given
+	// claims, e.g. on the same NUMA node. satisfied is false if co-located devices had to be claimed.
+	ClaimAvoiding(quantity resource.Quantity, avoid []ResourceClaim) (claim ResourceClaim, satisfied bool, err error)
+}
+
+// antiAffinityClaims returns the outstanding claims of the resource holding the given anti-affinity key.
+func (c *claimer) antiAffinityClaims(resourceName v1alpha1.ResourceName, key string) []ResourceClaim {
+	var claims []ResourceClaim
+	for _, entry := range c.issued {
+		if entry.resourceName == resourceName && entry.antiAffinityKey == key {
+			claims = append(claims, entry.claim)
+		}
+	}
+	return claims
+}
+
+// claimWithAntiAffinity claims the resource avoiding the outstanding claims of the anti-affinity key of opts.
+// ok is false if there are no such claims or the plugin does not support anti-affinity.
+func (c *claimer) claimWithAntiAffinity(
+	plugin Plugin,
+	resourceName v1alpha1.ResourceName,
+	quantity resource.Quantity,
+	opts ClaimOptions,
+) (resourceClaim ResourceClaim, ok bool, err error) {
+	antiAffinityPlugin, ok := plugin.(AntiAffinityPlugin)
+	if opts.AntiAffinityKey == "" || !ok {
+		return nil, false, nil
+	}
+
+	avoid := c.antiAffinityClaims(resourceName, opts.AntiAffinityKey)
+	if len(avoid) == 0 {
+		return nil, false, nil
+	}
+
+	opts.result.setStrategy(resourceName, ClaimStrategyAntiAffinity)
+	resourceClaim, satisfied, err := antiAffinityPlugin.ClaimAvoiding(quantity, avoid)
+	if err != nil {
+		return nil, true, err
+	}
+	if !satisfied {
+		opts.result.warnf("%s: anti-affinity %q not satisfied, fell back to co-located devices", resourceName, opts.AntiAffinityKey)
+	}
+	return resourceClaim, true, nil
+}
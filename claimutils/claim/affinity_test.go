@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Anti-Affinity", func() {
+	It("should place claims of the same key on disjoint NUMA nodes and switches", func(ctx SpecContext) {
+		// each gpu sits below a downstream port of a switch attached to a root port, the gpus share the
+		// upstream port of their switch as Parent, 0x17 and 0x18 below different downstream ports
+		switchA, switchB, switchC := &pci.Address{Bus: 0x15}, &pci.Address{Bus: 0x19}, &pci.Address{Bus: 0x95}
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockInfoReader{
+				infos: []pci.DeviceInfo{
+					{Address: pci.Address{Bus: 0x17}, NUMANode: ptr.To(0), Parent: switchA},
+					{Address: pci.Address{Bus: 0x18}, NUMANode: ptr.To(0), Parent: switchA},
+					{Address: pci.Address{Bus: 0x1b}, NUMANode: ptr.To(0), Parent: switchB},
+					{Address: pci.Address{Bus: 0x97}, NUMANode: ptr.To(1), Parent: switchC},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		resources := v1alpha1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}
+
+		By("claiming the first replica without prior claims")
+		claims, result, err := resourceClaimer.ClaimWithResult(ctx, resources, claim.WithAntiAffinity("ha"), claim.WithNUMANode(0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Strategies).To(HaveKeyWithValue(v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategyNUMAAffinity))
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x17}}))
+
+		By("claiming the second replica on another NUMA node and switch")
+		claims, result, err = resourceClaimer.ClaimWithResult(ctx, resources, claim.WithAntiAffinity("ha"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Strategies).To(HaveKeyWithValue(v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategyAntiAffinity))
+		Expect(result.Warnings).To(BeEmpty())
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x97}}))
+
+		By("falling back to the least co-located device once all NUMA nodes are taken")
+		claims, result, err = resourceClaimer.ClaimWithResult(ctx, resources, claim.WithAntiAffinity("ha"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Warnings).To(ConsistOf(ContainSubstring("anti-affinity")))
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x1b}}))
+
+		By("ignoring claims of other keys")
+		claims, result, err = resourceClaimer.ClaimWithResult(ctx, resources, claim.WithAntiAffinity("other"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Strategies).To(HaveKeyWithValue(v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategyDefault))
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x18}}))
+	})
+})
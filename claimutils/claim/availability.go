@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// CapacityPlugin is implemented by plugins that report their capacity.
+type CapacityPlugin interface {
+	Plugin
+	// Capacity returns the total quantity managed by the plugin.
+	Capacity() resource.Quantity
+	// Available returns the quantity that is not claimed.
+	Available() resource.Quantity
+}
+
+// Availability is the current availability of a resource.
+type Availability struct {
+	Free  resource.Quantity
+	Total resource.Quantity
+	// Reserved is the part of the claimed quantity held by reservations not committed yet.
+	Reserved resource.Quantity
+}
+
+// Availability returns the availability of every resource whose plugin implements CapacityPlugin
+// or DeviceLister, or wraps such a plugin, see pluginCapacity.
+func (c *claimer) Availability(ctx context.Context) (map[v1alpha1.ResourceName]Availability, error) {
+	var availability map[v1alpha1.ResourceName]Availability
+	if err := c.exec(ctx, func() {
+		reserved := c.reservedQuantities()
+
+		availability = make(map[v1alpha1.ResourceName]Availability, len(c.resources))
+		for resourceName, plugin := range c.resources {
+			free, total, ok := pluginCapacity(plugin)
+			if !ok {
+				continue
+			}
+
+			availability[resourceName] = Availability{
+				Free:     free,
+				Total:    total,
+				Reserved: reserved[resourceName],
+			}
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return availability, nil
+}
+
+// Capacity returns the total and free quantities of every resource whose plugin implements
+// CapacityPlugin or DeviceLister, or wraps such a plugin, observed in one pass of the claimer loop.
+func (c *claimer) Capacity(ctx context.Context) (total, free v1alpha1.ResourceList, err error) {
+	if err = c.exec(ctx, func() {
+		total = make(v1alpha1.ResourceList, len(c.resources))
+		free = make(v1alpha1.ResourceList, len(c.resources))
+		for resourceName, plugin := range c.resources {
+			pluginFree, pluginTotal, ok := pluginCapacity(plugin)
+			if !ok {
+				continue
+			}
+			total[resourceName] = pluginTotal
+			free[resourceName] = pluginFree
+		}
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return total, free, nil
+}
+
+// capacityReporter is implemented by the plugins wrapping other plugins to report the capacity of the
+// wrapped plugins.
+type capacityReporter interface {
+	capacity() (free, total resource.Quantity, ok bool)
+}
+
+// pluginCapacity returns the free and total quantities of the plugin, ok is false if it reports neither
+// its capacity nor its devices. Plugins returned by NewReservingPlugin, NewFallbackPlugin and
+// NewLazyPlugin report the capacity of the plugins they wrap, the remote plugin reports none.
+func pluginCapacity(plugin Plugin) (free, total resource.Quantity, ok bool) {
+	switch p := plugin.(type) {
+	case capacityReporter:
+		return p.capacity()
+	case CapacityPlugin:
+		return p.Available(), p.Capacity(), true
+	case DeviceLister:
+		var freeDevices, totalDevices int64
+		for _, deviceID := range p.ListDevices() {
+			totalDevices++
+			if isFree, err := p.IsDeviceFree(deviceID); err == nil && isFree {
+				freeDevices++
+			}
+		}
+		return *resource.NewQuantity(freeDevices, resource.DecimalSI), *resource.NewQuantity(totalDevices, resource.DecimalSI), true
+	default:
+		return resource.Quantity{}, resource.Quantity{}, false
+	}
+}
+
+// reservedQuantities sums the claimed quantities of the claims held by reservations per resource.
+func (c *claimer) reservedQuantities() map[v1alpha1.ResourceName]resource.Quantity {
+	reserved := map[v1alpha1.ResourceName]resource.Quantity{}
+	for _, reservation := range c.reservations {
+		for resourceName, resourceClaim := range reservation.Claims {
+			i := c.issuedIndex(resourceName, resourceClaim)
+			if i < 0 {
+				continue
+			}
+
+			quantity := reserved[resourceName]
+			quantity.Add(c.issued[i].claimed)
+			reserved[resourceName] = quantity
+		}
+	}
+	return reserved
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Availability", func() {
+	It("should reflect claims, reservations and releases", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+					{Function: 2},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		gpuAvailability := func() claim.Availability {
+			availability, err := resourceClaimer.Availability(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(availability).To(HaveLen(1))
+			return availability["nvidia.com/gpu"]
+		}
+		expectAvailability := func(free, total, reserved int64) {
+			GinkgoHelper()
+			availability := gpuAvailability()
+			Expect(availability.Free.Value()).To(Equal(free))
+			Expect(availability.Total.Value()).To(Equal(total))
+			Expect(availability.Reserved.Value()).To(Equal(reserved))
+		}
+
+		By("reporting all devices free")
+		expectAvailability(3, 3, 0)
+
+		By("claiming and reserving devices")
+		claims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		reservation, err := resourceClaimer.Reserve(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		expectAvailability(1, 3, 1)
+
+		By("committing the reservation")
+		_, err = resourceClaimer.Commit(ctx, reservation.ID)
+		Expect(err).NotTo(HaveOccurred())
+		expectAvailability(1, 3, 0)
+
+		By("releasing the claim")
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+		expectAvailability(2, 3, 0)
+	})
+
+	It("should report the aggregate capacity of all plugins", func(ctx SpecContext) {
+		newGPUPlugin := func() claim.Plugin {
+			return gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+				},
+			}, nil)
+		}
+		lazyPlugin := claim.NewLazyPlugin("example.com/lazy", func() (claim.Plugin, error) {
+			return claimtest.NewFakePlugin("example.com/lazy", 3), nil
+		})
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			newGPUPlugin(),
+			claimtest.NewFakePlugin("example.com/fake", 4),
+			claim.NewReservingPlugin(claimtest.NewFakePlugin("example.com/reserving", 4), 1),
+			claim.NewFallbackPlugin("example.com/fallback",
+				claimtest.NewFakePlugin("example.com/primary", 1),
+				claimtest.NewFakePlugin("example.com/secondary", 2),
+			),
+			lazyPlugin,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu":   resource.MustParse("1"),
+			"example.com/lazy": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		total, free, err := resourceClaimer.Capacity(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		expectQuantities := func(quantities v1alpha1.ResourceList, expected map[v1alpha1.ResourceName]int64) {
+			GinkgoHelper()
+			Expect(quantities).To(HaveLen(len(expected)))
+			for resourceName, value := range expected {
+				Expect(quantities).To(HaveKey(resourceName))
+				Expect(quantities.Name(resourceName, resource.DecimalSI).Value()).To(Equal(value), string(resourceName))
+			}
+		}
+		expectQuantities(total, map[v1alpha1.ResourceName]int64{
+			"nvidia.com/gpu":        2,
+			"example.com/fake":      4,
+			"example.com/reserving": 4,
+			"example.com/fallback":  3,
+			"example.com/lazy":      3,
+		})
+		expectQuantities(free, map[v1alpha1.ResourceName]int64{
+			"nvidia.com/gpu":        1,
+			"example.com/fake":      4,
+			"example.com/reserving": 3,
+			"example.com/fallback":  3,
+			"example.com/lazy":      2,
+		})
+	})
+})
@@ -7,10 +7,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/eventutils/recorder"
+	"k8s.io/utils/clock"
 )
 
 var (
@@ -18,106 +24,357 @@ var (
 	ErrReleaseClaim   = errors.New("failed to release claim")
 	ErrAlreadyStarted = errors.New("claimer already started")
 	ErrNotStarted     = errors.New("claimer not running")
+
+	ErrPluginInitTimeout = errors.New("plugin init timed out")
 )
 
+// Claims holds the claim per resource. Claim values are treated as immutable, only the map itself is copied.
 type Claims map[v1alpha1.ResourceName]ResourceClaim
 
+// Clone returns a shallow copy of the claims, whose resources can be added or removed without
+// affecting the original. The claim values are shared.
+func (c Claims) Clone() Claims {
+	if c == nil {
+		return nil
+	}
+
+	out := make(Claims, len(c))
+	for resourceName, resourceClaim := range c {
+		out[resourceName] = resourceClaim
+	}
+	return out
+}
+
 type Claimer interface {
-	Claim(ctx context.Context, resources v1alpha1.ResourceList) (Claims, error)
+	Claim(ctx context.Context, resources v1alpha1.ResourceList, opts ...ClaimOption) (Claims, error)
 	Release(ctx context.Context, claims Claims) error
 	Start(ctx context.Context) error
 	WaitUntilStarted(ctx context.Context) error
+	// Capacity returns the total and free quantities of the resources whose plugin reports them.
+	Capacity(ctx context.Context) (total, free v1alpha1.ResourceList, err error)
+	// CanClaimAll reports whether a Claim of the resources would currently succeed without claiming them.
+	CanClaimAll(ctx context.Context, resources v1alpha1.ResourceList, opts ...ClaimOption) (bool, error)
+}
+
+// ClaimerOptions defines options to initialize the resource claimer.
+type ClaimerOptions struct {
+	// Clock is used for reservation expiry and lease reaping. Defaults to the real clock.
+	Clock clock.WithTicker
+	// ReapInterval is the interval in which expired reservations are released.
+	ReapInterval time.Duration
+	// DryRunHold, if set, is the duration CanClaimAll holds claimable resources for, so concurrent
+	// checks don't report the same resources as claimable. Holds don't claim resources.
+	DryRunHold time.Duration
+	// QuotaProvider, if set, limits the resources an identity may hold.
+	QuotaProvider QuotaProvider
+	// Registry holds the codecs used to snapshot and restore claims.
+	Registry *Registry
+	// Preemptor, if set, selects lower-priority claims to release when a claim cannot be satisfied.
+	Preemptor Preemptor
+	// EventRecorder, if set, records failed claims of machines given via WithMachineMetadata.
+	EventRecorder recorder.EventRecorder
+	// RecordAllocations additionally records the devices of successful claims and claims failing for
+	// other reasons than insufficient resources via the EventRecorder.
+	RecordAllocations bool
+	// OperationTimeout, if set, bounds every operation, so a wedged loop surfaces
+	// context.DeadlineExceeded instead of blocking the caller. The earlier of the timeout and the
+	// deadline of the caller's context applies.
+	OperationTimeout time.Duration
+	// InitTimeout, if set, bounds the Init of every plugin during construction. A plugin exceeding it
+	// fails the construction with ErrPluginInitTimeout, its Init keeps running in the background.
+	InitTimeout time.Duration
+	// CaptureClaimStacks records the stack of every Claim, ClaimWithResult, ClaimIdempotent and Reserve
+	// caller, retrievable via ListIssued.
+	// It is meant for debugging leaked claims and adds considerable overhead to every claim.
+	CaptureClaimStacks bool
+	// UtilizationSampleInterval, if set, is the interval the utilization of resources is sampled in,
+	// see UtilizationHistory.
+	UtilizationSampleInterval time.Duration
+	// UtilizationHistorySize is the number of samples kept per resource. Defaults to 60.
+	UtilizationHistorySize int
+	// ValidateDeviceUniqueness fails the construction with ErrOverlappingDevices if plugins implementing
+	// DeviceLister manage the same device after their Init.
+	ValidateDeviceUniqueness bool
+	// SelectionPolicy is passed to the Claim of plugins implementing PolicyAware. It does not apply
+	// to claims with NUMA affinity.
+	SelectionPolicy SelectionPolicy
+	// MetricsCollector, if set, receives the processing latency of claim and release requests.
+	MetricsCollector MetricsCollector
+	// ResourceSchema, if set, restricts claims to the declared resources and quantities. Claims
+	// violating it fail with ErrResourceSchemaViolation before any plugin is asked.
+	ResourceSchema ResourceSchema
+}
+
+func (o *ClaimerOptions) Defaults() {
+	if o.Clock == nil {
+		o.Clock = clock.RealClock{}
+	}
+
+	if o.ReapInterval <= 0 {
+		o.ReapInterval = 10 * time.Second
+	}
+
+	if o.Registry == nil {
+		o.Registry = NewRegistry()
+	}
+
+	if o.UtilizationHistorySize <= 0 {
+		o.UtilizationHistorySize = 60
+	}
 }
 
 func NewResourceClaimer(log logr.Logger, plugins ...Plugin) (*claimer, error) {
+	return NewResourceClaimerWithOptions(log, ClaimerOptions{}, plugins...)
+}
+
+func NewResourceClaimerWithOptions(log logr.Logger, opts ClaimerOptions, plugins ...Plugin) (*claimer, error) {
+	opts.Defaults()
+
 	c := claimer{
-		log:     log,
-		plugins: map[string]Plugin{},
+		log:       log,
+		plugins:   map[string]Plugin{},
+		resources: map[v1alpha1.ResourceName]Plugin{},
+
+		initTimeout:     opts.InitTimeout,
+		validateDevices: opts.ValidateDeviceUniqueness,
+
+		clock:        opts.Clock,
+		reapInterval: opts.ReapInterval,
+		reservations: map[string]*Reservation{},
+		dryRunHold:   opts.DryRunHold,
+
+		schema:        opts.ResourceSchema,
+		quotaProvider: opts.QuotaProvider,
+		registry:      opts.Registry,
+		preemptor:     opts.Preemptor,
+		eventRecorder: opts.EventRecorder,
+
+		recordAllocations: opts.RecordAllocations,
+
+		operationTimeout:   opts.OperationTimeout,
+		captureClaimStacks: opts.CaptureClaimStacks,
+		selectionPolicy:    opts.SelectionPolicy,
+		metricsCollector:   opts.MetricsCollector,
+
+		utilizationSampleInterval: opts.UtilizationSampleInterval,
+		utilizationHistorySize:    opts.UtilizationHistorySize,
+		utilization:               map[v1alpha1.ResourceName]*utilizationRing{},
+
+		cordoned: map[v1alpha1.ResourceName]bool{},
 
 		toClaim:   make(chan claimReq, 1),
 		toRelease: make(chan releaseReq, 1),
+		toExec:    make(chan execReq, 1),
+		toStop:    make(chan stopReq, 1),
 
 		started:  make(chan struct{}),
+		stopping: make(chan struct{}),
 		shutdown: make(chan struct{}),
+		exited:   make(chan struct{}),
 	}
 
 	for _, plugin := range plugins {
-		if _, existing := c.plugins[plugin.Name()]; existing {
-			return nil, fmt.Errorf("plugin %s already exists", plugin.Name())
+		if err := c.addPlugin(plugin); err != nil {
+			return nil, err
 		}
-		c.plugins[plugin.Name()] = plugin
 	}
 
 	for _, plugin := range c.plugins {
-		if err := plugin.Init(); err != nil {
+		if err := c.initPlugin(plugin, opts.InitTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.ValidateDeviceUniqueness {
+		if err := c.validateDeviceUniqueness(); err != nil {
 			return nil, err
 		}
 	}
 	return &c, nil
 }
 
+func (c *claimer) initPlugin(plugin Plugin, timeout time.Duration) error {
+	if timeout <= 0 {
+		return plugin.Init()
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- plugin.Init()
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-c.clock.After(timeout):
+		return fmt.Errorf("plugin %s: %w", plugin.Name(), ErrPluginInitTimeout)
+	}
+}
+
 type claimer struct {
-	log     logr.Logger
-	plugins map[string]Plugin
+	log logr.Logger
+	// pluginsMu guards plugins and resources. They are only changed by the loop, which reads them
+	// without holding it.
+	pluginsMu sync.RWMutex
+	plugins   map[string]Plugin
+	resources map[v1alpha1.ResourceName]Plugin
+
+	initTimeout     time.Duration
+	validateDevices bool
+
+	clock           clock.WithTicker
+	reapInterval    time.Duration
+	reservations    map[string]*Reservation
+	nextReservation uint64
+	dryRunHold      time.Duration
+	holds           []hold
+
+	schema        ResourceSchema
+	quotaProvider QuotaProvider
+	registry      *Registry
+	preemptor     Preemptor
+	eventRecorder recorder.EventRecorder
+
+	recordAllocations bool
+
+	operationTimeout   time.Duration
+	captureClaimStacks bool
+	selectionPolicy    SelectionPolicy
+	metricsCollector   MetricsCollector
+
+	utilizationSampleInterval time.Duration
+	utilizationHistorySize    int
+	utilizationMu             sync.Mutex
+	utilization               map[v1alpha1.ResourceName]*utilizationRing
+
+	cordonMu sync.Mutex
+	cordoned map[v1alpha1.ResourceName]bool
+
+	issued          []issuedClaim
+	nextIssuedGroup uint64
 
 	toClaim   chan claimReq
 	toRelease chan releaseReq
+	toExec    chan execReq
+	toStop    chan stopReq
+
+	// claimQueueDepth and releaseQueueDepth count the requests waiting to be received by the loop.
+	claimQueueDepth   atomic.Int64
+	releaseQueueDepth atomic.Int64
 
 	startOnce sync.Once
 	started   chan struct{}
+	stopOnce  sync.Once
+	stopping  chan struct{}
 	shutdown  chan struct{}
+	exited    chan struct{}
 }
 
 type claimRes struct {
 	claims Claims
+	result ClaimResult
 	err    error
 }
 
 type claimReq struct {
 	resources  v1alpha1.ResourceList
+	opts       ClaimOptions
+	queued     time.Time
 	resultChan chan claimRes
 }
 
 type releaseReq struct {
 	claims     Claims
+	queued     time.Time
 	resultChan chan error
 }
 
+type execReq struct {
+	fn   func()
+	done chan error
+}
+
+type stopReq struct {
+	ctx     context.Context
+	pending chan int
+}
+
 func (c *claimer) start(ctx context.Context) {
-	defer func() {
-		for req := range c.toClaim {
-			req.resultChan <- claimRes{err: ctx.Err()}
-		}
-		for req := range c.toRelease {
-			req.resultChan <- ctx.Err()
-		}
+	defer close(c.exited)
 
-		close(c.toClaim)
-		close(c.toRelease)
-	}()
+	reaper := c.clock.NewTicker(c.reapInterval)
+	defer reaper.Stop()
+
+	var sample <-chan time.Time
+	if c.utilizationSampleInterval > 0 {
+		sampler := c.clock.NewTicker(c.utilizationSampleInterval)
+		defer sampler.Stop()
+		sample = sampler.C()
+	}
 
 	close(c.started)
 
 	for {
+		// A stop request takes precedence over queued requests, so the deadline of its context holds
+		select {
+		case req := <-c.toStop:
+			c.stop(ctx, req)
+			return
+		default:
+		}
+
 		select {
 		case <-ctx.Done():
 			close(c.shutdown)
+			c.stopAccepting()
+			c.rejectQueued(ctx.Err())
+			c.shutdownPlugins(ctx)
 			return
-		case req := <-c.toClaim:
-			res := claimRes{}
-			res.claims, res.err = c.claim(req.resources)
-			req.resultChan <- res
+		case req := <-c.toStop:
+			c.stop(ctx, req)
+			return
+		case <-reaper.C():
+			c.reapReservations()
+		case <-sample:
+			c.sampleUtilization()
 
+		case req := <-c.toExec:
+			c.handleExec(req)
+		case req := <-c.toClaim:
+			c.handleClaim(req)
 		case req := <-c.toRelease:
-			if err := c.release(req.claims); err != nil {
-				req.resultChan <- errors.Join(ErrReleaseClaim, err)
-			} else {
-				req.resultChan <- nil
-			}
+			c.handleRelease(req)
 		}
 	}
 }
 
+func (c *claimer) handleExec(req execReq) {
+	req.fn()
+	req.done <- nil
+}
+
+func (c *claimer) handleClaim(req claimReq) {
+	c.claimQueueDepth.Add(-1)
+	res := claimRes{}
+	req.opts.result = &res.result
+	res.claims, res.err = c.claim(req.resources, req.opts)
+	req.resultChan <- res
+	c.observeLatency(OperationClaim, req.queued)
+}
+
+func (c *claimer) handleRelease(req releaseReq) {
+	c.releaseQueueDepth.Add(-1)
+	// Plugins may have been unregistered since the caller checked
+	if err := c.checkPluginsForClaims(req.claims); err != nil {
+		req.resultChan <- errors.Join(ErrMissingPlugins, err)
+	} else if err := c.release(req.claims); err != nil {
+		req.resultChan <- errors.Join(ErrReleaseClaim, err)
+	} else {
+		req.resultChan <- nil
+	}
+	c.observeLatency(OperationRelease, req.queued)
+}
+
 func (c *claimer) Start(ctx context.Context) error {
 	var called bool
 	c.startOnce.Do(func() {
@@ -129,11 +386,43 @@ func (c *claimer) Start(ctx context.Context) error {
 		return ErrAlreadyStarted
 	}
 
-	<-ctx.Done()
+	<-c.exited
 
 	return nil
 }
 
+// exec runs fn on the serialized claimer loop and waits until it returned.
+func (c *claimer) exec(ctx context.Context, fn func()) error {
+	ctx, cancel := c.withOperationTimeout(ctx)
+	defer cancel()
+
+	if err := c.ensureRunning(); err != nil {
+		return err
+	}
+
+	req := execReq{
+		fn:   fn,
+		done: make(chan error, 1),
+	}
+	if err := enqueue(ctx, c, c.toExec, req); err != nil {
+		return err
+	}
+
+	err, awaitErr := await(ctx, c, req.done)
+	if awaitErr != nil {
+		return awaitErr
+	}
+	return err
+}
+
+// withOperationTimeout applies the operation timeout, an earlier deadline of ctx is kept.
+func (c *claimer) withOperationTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.operationTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.operationTimeout)
+}
+
 func (c *claimer) ensureRunning() error {
 	select {
 	case <-c.started:
@@ -150,43 +439,93 @@ func (c *claimer) ensureRunning() error {
 	return nil
 }
 
-func (c *claimer) claim(resources v1alpha1.ResourceList) (Claims, error) {
-	var insufficientResourceErrors []error
-	for resourceName := range resources {
-		plugin := c.plugins[string(resourceName)]
+func (c *claimer) claim(resources v1alpha1.ResourceList, opts ClaimOptions) (Claims, error) {
+	claims, err := c.tryClaim(resources, opts)
+	c.recordClaim(resources, opts, claims, err)
+	return claims, err
+}
+
+func (c *claimer) tryClaim(resources v1alpha1.ResourceList, opts ClaimOptions) (Claims, error) {
+	// Plugins may have been unregistered since the caller checked
+	if err := c.checkPluginsForResources(resources); err != nil {
+		return nil, errors.Join(ErrMissingPlugins, err)
+	}
+
+	if err := checkQuantities(resources); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkCordoned(resources); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkQuota(opts.Identity, resources); err != nil {
+		return nil, err
+	}
+
+	claims, err := c.claimResources(resources, opts)
+	if errors.Is(err, ErrInsufficientResources) {
+		if preempted := c.preempt(resources, opts); preempted != nil {
+			if claims, err = c.claimResources(resources, opts); err != nil {
+				c.restorePreempted(preempted)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.endHold(resources, opts)
+	return claims, nil
+}
+
+func (c *claimer) claimResources(resources v1alpha1.ResourceList, opts ClaimOptions) (Claims, error) {
+	// Diagnostics of a previous attempt, e.g. before preempting, don't apply to this one
+	*opts.result = ClaimResult{}
+
+	var insufficientResourceErrors ClaimErrors
+	for _, resourceName := range slices.Sorted(maps.Keys(resources)) {
+		plugin := c.resources[resourceName]
 		if !plugin.CanClaim(resources[resourceName]) {
 			insufficientResourceErrors = append(
 				insufficientResourceErrors,
-				fmt.Errorf("insufficient resource for %s", resourceName),
+				newClaimError(plugin, resourceName, resources[resourceName], ErrInsufficientResources),
 			)
 		}
 	}
 	if len(insufficientResourceErrors) > 0 {
-		return nil, errors.Join(ErrInsufficientResources, errors.Join(insufficientResourceErrors...))
+		return nil, insufficientResourceErrors
 	}
 
 	claims := map[v1alpha1.ResourceName]ResourceClaim{}
 	for resourceName := range resources {
-		plugin := c.plugins[string(resourceName)]
+		plugin := c.resources[resourceName]
 
-		claim, claimErr := plugin.Claim(resources[resourceName])
+		claim, claimErr := c.claimResource(plugin, resourceName, resources[resourceName], opts)
 		if claimErr != nil {
 			if err := c.release(claims); err != nil {
 				c.log.Error(errors.Join(ErrReleaseClaim, err), "failed to release claim ")
 			}
-			return nil, claimErr
+			return nil, ClaimErrors{newClaimError(plugin, resourceName, resources[resourceName], claimErr)}
 		}
 
+		opts.result.pluginWarnings(plugin, resourceName, claim)
 		claims[resourceName] = claim
 	}
 
+	c.recordIssued(opts, resources, claims)
+	opts.result.ID = claimIDOf(c.nextIssuedGroup)
+
 	return claims, nil
 }
 
 func (c *claimer) checkPluginsForResources(resources v1alpha1.ResourceList) error {
+	c.pluginsMu.RLock()
+	defer c.pluginsMu.RUnlock()
+
 	var missingPluginErrors []error
 	for resourceName := range resources {
-		if _, ok := c.plugins[string(resourceName)]; !ok {
+		if _, ok := c.resources[resourceName]; !ok {
 			missingPluginErrors = append(missingPluginErrors, fmt.Errorf("plugin for resource %s not found", resourceName))
 		}
 	}
@@ -197,42 +536,64 @@ func (c *claimer) checkPluginsForResources(resources v1alpha1.ResourceList) erro
 	return nil
 }
 
-func (c *claimer) Claim(ctx context.Context, resources v1alpha1.ResourceList) (Claims, error) {
+func (c *claimer) Claim(ctx context.Context, resources v1alpha1.ResourceList, opts ...ClaimOption) (Claims, error) {
+	claims, _, err := c.claimWithResult(ctx, c.callerStack(), resources, opts...)
+	return claims, err
+}
+
+func (c *claimer) claimWithResult(
+	ctx context.Context,
+	stack []uintptr,
+	resources v1alpha1.ResourceList,
+	opts ...ClaimOption,
+) (Claims, ClaimResult, error) {
+	if err := c.checkSchema(resources); err != nil {
+		return nil, ClaimResult{}, err
+	}
+
 	if err := c.checkPluginsForResources(resources); err != nil {
-		return nil, errors.Join(ErrMissingPlugins, err)
+		return nil, ClaimResult{}, errors.Join(ErrMissingPlugins, err)
 	}
 
 	if err := c.ensureRunning(); err != nil {
-		return nil, err
+		return nil, ClaimResult{}, err
 	}
 
+	ctx, cancel := c.withOperationTimeout(ctx)
+	defer cancel()
+
 	req := claimReq{
 		resources:  resources,
+		opts:       newClaimOptions(opts),
+		queued:     c.clock.Now(),
 		resultChan: make(chan claimRes, 1),
 	}
-	select {
-	case c.toClaim <- req:
-	case <-c.shutdown:
-		return nil, ErrNotStarted
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	req.opts.stack = stack
+	c.claimQueueDepth.Add(1)
+	if err := enqueue(ctx, c, c.toClaim, req); err != nil {
+		c.claimQueueDepth.Add(-1)
+		return nil, ClaimResult{}, err
 	}
 
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case res := <-req.resultChan:
-		return res.claims, res.err
+	res, err := await(ctx, c, req.resultChan)
+	if err != nil {
+		if errors.Is(err, ErrNotStarted) {
+			c.claimQueueDepth.Add(-1)
+		}
+		return nil, ClaimResult{}, err
 	}
+	return res.claims.Clone(), res.result, res.err
 }
 
 func (c *claimer) release(claims Claims) error {
+	c.forgetIssued(claims)
+
 	var releaseErrors []error
 	for resourceName := range claims {
-		plugin := c.plugins[string(resourceName)]
+		plugin := c.resources[resourceName]
 
 		if err := plugin.Release(claims[resourceName]); err != nil {
-			releaseErrors = append(releaseErrors, err)
+			releaseErrors = append(releaseErrors, fmt.Errorf("failed to release %s: %w", resourceName, err))
 		}
 	}
 	if len(releaseErrors) > 0 {
@@ -243,9 +604,12 @@ func (c *claimer) release(claims Claims) error {
 }
 
 func (c *claimer) checkPluginsForClaims(claims Claims) error {
+	c.pluginsMu.RLock()
+	defer c.pluginsMu.RUnlock()
+
 	var missingPluginErrors []error
 	for resourceName := range claims {
-		if _, ok := c.plugins[string(resourceName)]; !ok {
+		if _, ok := c.resources[resourceName]; !ok {
 			missingPluginErrors = append(missingPluginErrors, fmt.Errorf("plugin for resource %s not found", resourceName))
 		}
 	}
@@ -264,24 +628,29 @@ func (c *claimer) Release(ctx context.Context, claims Claims) error {
 	if err := c.ensureRunning(); err != nil {
 		return err
 	}
+
+	ctx, cancel := c.withOperationTimeout(ctx)
+	defer cancel()
+
 	req := releaseReq{
-		claims:     claims,
+		claims:     claims.Clone(),
+		queued:     c.clock.Now(),
 		resultChan: make(chan error, 1),
 	}
-	select {
-	case c.toRelease <- req:
-	case <-c.shutdown:
-		return ErrNotStarted
-	case <-ctx.Done():
-		return ctx.Err()
+	c.releaseQueueDepth.Add(1)
+	if err := enqueue(ctx, c, c.toRelease, req); err != nil {
+		c.releaseQueueDepth.Add(-1)
+		return err
 	}
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case res := <-req.resultChan:
-		return res
+	res, err := await(ctx, c, req.resultChan)
+	if err != nil {
+		if errors.Is(err, ErrNotStarted) {
+			c.releaseQueueDepth.Add(-1)
+		}
+		return err
 	}
+	return res
 }
 
 func (c *claimer) WaitUntilStarted(ctx context.Context) error {
@@ -292,3 +661,18 @@ func (c *claimer) WaitUntilStarted(ctx context.Context) error {
 		return ctx.Err()
 	}
 }
+
+func (c *claimer) addPlugin(plugin Plugin) error {
+	if _, existing := c.plugins[plugin.Name()]; existing {
+		return &RegistrationError{Identifier: plugin.Name(), Err: ErrDuplicatePlugin}
+	}
+
+	resourceName := ResourceNameOf(plugin)
+	if _, existing := c.resources[resourceName]; existing {
+		return &RegistrationError{Identifier: string(resourceName), Err: ErrDuplicateResource}
+	}
+
+	c.plugins[plugin.Name()] = plugin
+	c.resources[resourceName] = plugin
+	return nil
+}
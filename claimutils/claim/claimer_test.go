@@ -5,6 +5,8 @@ package claim_test
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
 	"github.com/ironcore-dev/provider-utils/claimutils/claim"
@@ -25,6 +27,45 @@ func (m *mockReader) Read() ([]pci.Address, error) {
 	return m.devices, m.err
 }
 
+func startClaimer(ctx context.Context, resourceClaimer claim.Claimer) {
+	innerCtx, cancel := context.WithCancel(ctx)
+	DeferCleanup(cancel)
+	go func() {
+		defer GinkgoRecover()
+		Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+	}()
+	Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+}
+
+type namedResourcePlugin struct {
+	claim.Plugin
+	resourceName v1alpha1.ResourceName
+}
+
+func (p *namedResourcePlugin) ResourceName() v1alpha1.ResourceName {
+	return p.resourceName
+}
+
+type blockingPlugin struct {
+	claim.Plugin
+	unblock chan struct{}
+}
+
+func (p *blockingPlugin) Claim(quantity resource.Quantity) (claim.ResourceClaim, error) {
+	<-p.unblock
+	return p.Plugin.Claim(quantity)
+}
+
+type hangingInitPlugin struct {
+	claim.Plugin
+	unblock chan struct{}
+}
+
+func (p *hangingInitPlugin) Init() error {
+	<-p.unblock
+	return nil
+}
+
 var _ = Describe("Resource Claimer", func() {
 	It("should claim composite resources", func(ctx SpecContext) {
 		By("init plugin")
@@ -93,4 +134,120 @@ var _ = Describe("Resource Claimer", func() {
 
 	})
 
+	It("should report plugin name collisions", func(ctx SpecContext) {
+		_, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{}, nil),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{}, nil),
+		)
+		Expect(err).To(MatchError(claim.ErrDuplicatePlugin))
+		Expect(err).NotTo(MatchError(claim.ErrDuplicateResource))
+
+		var registrationErr *claim.RegistrationError
+		Expect(errors.As(err, &registrationErr)).To(BeTrue())
+		Expect(registrationErr.Identifier).To(Equal("nvidia.com/gpu"))
+	})
+
+	It("should report resource collisions", func(ctx SpecContext) {
+		_, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{}, nil),
+			&namedResourcePlugin{
+				Plugin:       gpu.NewGPUClaimPlugin(log.FromContext(ctx), "secondary-gpu", &mockReader{}, nil),
+				resourceName: "nvidia.com/gpu",
+			},
+		)
+		Expect(err).To(MatchError(claim.ErrDuplicateResource))
+		Expect(err).NotTo(MatchError(claim.ErrDuplicatePlugin))
+
+		var registrationErr *claim.RegistrationError
+		Expect(errors.As(err, &registrationErr)).To(BeTrue())
+		Expect(registrationErr.Identifier).To(Equal("nvidia.com/gpu"))
+	})
+
+	It("should route claims to the plugin serving the resource", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			&namedResourcePlugin{
+				Plugin: gpu.NewGPUClaimPlugin(log.FromContext(ctx), "local-gpu", &mockReader{
+					devices: []pci.Address{{}},
+				}, nil),
+				resourceName: "nvidia.com/gpu",
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+
+		claims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims).To(HaveKey(v1alpha1.ResourceName("nvidia.com/gpu")))
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+	})
+
+	It("should apply the operation timeout to contexts with a later or no deadline", func(ctx SpecContext) {
+		unblock := make(chan struct{})
+		DeferCleanup(func() { close(unblock) })
+
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				OperationTimeout: 100 * time.Millisecond,
+			},
+			&blockingPlugin{
+				Plugin: gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+					devices: []pci.Address{{}},
+				}, nil),
+				unblock: unblock,
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("claiming with a context without deadline against a wedged plugin")
+		start := time.Now()
+		_, err = resourceClaimer.Claim(context.Background(), v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+
+		By("releasing while the loop is still wedged")
+		err = resourceClaimer.Release(context.Background(), claim.Claims{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		By("releasing with a context whose deadline is later than the timeout")
+		laterCtx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+		start = time.Now()
+		err = resourceClaimer.Release(laterCtx, claim.Claims{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		Expect(laterCtx.Err()).NotTo(HaveOccurred())
+	})
+
+	It("should fail construction if a plugin init exceeds the init timeout", func(ctx SpecContext) {
+		unblock := make(chan struct{})
+		DeferCleanup(func() { close(unblock) })
+
+		_, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				InitTimeout: 100 * time.Millisecond,
+			},
+			&hangingInitPlugin{
+				Plugin:  gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{}, nil),
+				unblock: unblock,
+			},
+		)
+		Expect(err).To(MatchError(claim.ErrPluginInitTimeout))
+	})
 })
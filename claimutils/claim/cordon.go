@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+)
+
+var (
+	ErrResourceCordoned = errors.New("resource cordoned")
+)
+
+// Cordon rejects new claims of the resource with ErrResourceCordoned until it is uncordoned.
+// Issued claims are not affected and can still be released, so the resource drains over time.
+func (c *claimer) Cordon(resourceName v1alpha1.ResourceName) error {
+	return c.setCordoned(resourceName, true)
+}
+
+// Uncordon accepts claims of a cordoned resource again.
+func (c *claimer) Uncordon(resourceName v1alpha1.ResourceName) error {
+	return c.setCordoned(resourceName, false)
+}
+
+// IsCordoned reports whether claims of the resource are rejected.
+func (c *claimer) IsCordoned(resourceName v1alpha1.ResourceName) bool {
+	c.cordonMu.Lock()
+	defer c.cordonMu.Unlock()
+
+	return c.cordoned[resourceName]
+}
+
+func (c *claimer) setCordoned(resourceName v1alpha1.ResourceName, cordoned bool) error {
+	c.pluginsMu.RLock()
+	_, ok := c.resources[resourceName]
+	c.pluginsMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingPlugins, resourceName)
+	}
+
+	c.cordonMu.Lock()
+	defer c.cordonMu.Unlock()
+
+	if cordoned {
+		c.cordoned[resourceName] = true
+	} else {
+		delete(c.cordoned, resourceName)
+	}
+	return nil
+}
+
+// checkCordoned fails with ErrResourceCordoned if any of the resources is cordoned.
+func (c *claimer) checkCordoned(resources v1alpha1.ResourceList) error {
+	c.cordonMu.Lock()
+	defer c.cordonMu.Unlock()
+
+	var cordonedErrors []error
+	for _, resourceName := range slices.Sorted(maps.Keys(resources)) {
+		if c.cordoned[resourceName] {
+			cordonedErrors = append(cordonedErrors, fmt.Errorf("%w: %s", ErrResourceCordoned, resourceName))
+		}
+	}
+	return errors.Join(cordonedErrors...)
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Cordon", func() {
+	It("should reject claims of cordoned resources while allowing releases", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}, {Function: 1}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		oneGPU := v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}
+
+		claims, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("cordoning the resource")
+		Expect(resourceClaimer.Cordon("nvidia.com/gpu")).To(Succeed())
+		Expect(resourceClaimer.IsCordoned("nvidia.com/gpu")).To(BeTrue())
+
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).To(MatchError(claim.ErrResourceCordoned))
+
+		By("releasing the issued claim while cordoned")
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+
+		By("claiming again after uncordoning")
+		Expect(resourceClaimer.Uncordon("nvidia.com/gpu")).To(Succeed())
+		Expect(resourceClaimer.IsCordoned("nvidia.com/gpu")).To(BeFalse())
+
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail to cordon resources without plugin", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(log.FromContext(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(resourceClaimer.Cordon("nvidia.com/gpu")).To(MatchError(claim.ErrMissingPlugins))
+	})
+})
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+)
+
+var (
+	ErrDeviceListingNotSupported = errors.New("plugin does not support device listing")
+)
+
+// DeviceLister is implemented by plugins managing individually identifiable devices.
+type DeviceLister interface {
+	Plugin
+	// ListDevices returns the ids of all managed devices.
+	ListDevices() []string
+	// IsDeviceFree reports whether the managed device with the given id is not claimed.
+	IsDeviceFree(deviceID string) (bool, error)
+}
+
+// pluginWrapper is implemented by the plugins wrapping a single plugin, nil if it was not created yet.
+type pluginWrapper interface {
+	wrapped() Plugin
+}
+
+// deviceListerOf returns the DeviceLister of the plugin, which is the plugin itself or the plugin wrapped
+// by NewReservingPlugin or NewLazyPlugin once created. Fallback plugins list no devices, the ids of their
+// plugins may collide.
+func deviceListerOf(plugin Plugin) (DeviceLister, bool) {
+	for plugin != nil {
+		switch p := plugin.(type) {
+		case DeviceLister:
+			return p, true
+		case pluginWrapper:
+			plugin = p.wrapped()
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// IsDeviceFree reports whether the device with the given id of the plugin serving the resource is not claimed.
+func (c *claimer) IsDeviceFree(ctx context.Context, resourceName v1alpha1.ResourceName, deviceID string) (bool, error) {
+	var (
+		free    bool
+		freeErr error
+	)
+	if err := c.exec(ctx, func() {
+		plugin, ok := c.resources[resourceName]
+		if !ok {
+			freeErr = fmt.Errorf("%w: %s", ErrMissingPlugins, resourceName)
+			return
+		}
+
+		lister, ok := deviceListerOf(plugin)
+		if !ok {
+			freeErr = fmt.Errorf("%w: %s", ErrDeviceListingNotSupported, resourceName)
+			return
+		}
+
+		free, freeErr = lister.IsDeviceFree(deviceID)
+	}); err != nil {
+		return false, err
+	}
+
+	return free, freeErr
+}
+
+// validateDeviceUniqueness returns a RegistrationError with ErrOverlappingDevices for every device
+// managed by more than one DeviceLister.
+func (c *claimer) validateDeviceUniqueness() error {
+	owners := map[string][]string{}
+	for name, plugin := range c.plugins {
+		lister, ok := deviceListerOf(plugin)
+		if !ok {
+			continue
+		}
+
+		for _, deviceID := range lister.ListDevices() {
+			owners[deviceID] = append(owners[deviceID], name)
+		}
+	}
+
+	var overlapping []error
+	for _, deviceID := range slices.Sorted(maps.Keys(owners)) {
+		plugins := owners[deviceID]
+		if len(plugins) < 2 {
+			continue
+		}
+
+		slices.Sort(plugins)
+		overlapping = append(overlapping, &RegistrationError{
+			Identifier: fmt.Sprintf("%s (plugins %s)", deviceID, strings.Join(plugins, ", ")),
+			Err:        ErrOverlappingDevices,
+		})
+	}
+
+	return errors.Join(overlapping...)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"errors"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Device Listing", func() {
+	It("should report whether a specific device is free", func(ctx SpecContext) {
+		devices := []pci.Address{{}, {Function: 1}}
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: devices,
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("claiming one device")
+		claims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		gpuClaim, ok := claims["nvidia.com/gpu"].(gpu.Claim)
+		Expect(ok).To(BeTrue())
+		Expect(gpuClaim.PCIAddresses()).To(HaveLen(1))
+
+		claimed := gpuClaim.PCIAddresses()[0]
+		sibling := devices[0]
+		if sibling == claimed {
+			sibling = devices[1]
+		}
+
+		By("reporting the claimed device as not free")
+		free, err := resourceClaimer.IsDeviceFree(ctx, "nvidia.com/gpu", claimed.String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(free).To(BeFalse())
+
+		By("reporting the sibling as free")
+		free, err = resourceClaimer.IsDeviceFree(ctx, "nvidia.com/gpu", sibling.String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(free).To(BeTrue())
+
+		By("failing for unmanaged devices and unknown resources")
+		_, err = resourceClaimer.IsDeviceFree(ctx, "nvidia.com/gpu", pci.Address{Bus: 1}.String())
+		Expect(err).To(MatchError(gpu.ErrDeviceNotManaged))
+
+		_, err = resourceClaimer.IsDeviceFree(ctx, "amd.com/gpu", claimed.String())
+		Expect(err).To(MatchError(claim.ErrMissingPlugins))
+	})
+
+	It("should reject plugins managing the same device", func(ctx SpecContext) {
+		newPlugin := func(name string) claim.Plugin {
+			return gpu.NewGPUClaimPlugin(log.FromContext(ctx), name, &mockReader{
+				devices: []pci.Address{{Bus: 0x17}},
+			}, nil)
+		}
+
+		By("allowing the overlap without validation")
+		_, err := claim.NewResourceClaimer(log.FromContext(ctx), newPlugin("nvidia.com/gpu"), newPlugin("gpu-b"))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("rejecting the overlap with validation")
+		_, err = claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{ValidateDeviceUniqueness: true},
+			newPlugin("nvidia.com/gpu"),
+			newPlugin("gpu-b"),
+		)
+		Expect(err).To(MatchError(claim.ErrOverlappingDevices))
+
+		var registrationErr *claim.RegistrationError
+		Expect(errors.As(err, &registrationErr)).To(BeTrue())
+		Expect(registrationErr.Identifier).To(ContainSubstring("0000:17:00.0"))
+	})
+})
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// hold records resources CanClaimAll reported as claimable to the identity that checked them.
+type hold struct {
+	identity  string
+	resources v1alpha1.ResourceList
+	expiresAt time.Time
+}
+
+// CanClaimAll reports whether a Claim of the resources with the given options would currently succeed.
+// It runs the checks of Claim, except for preemption, in a single pass of the claimer loop, asking the
+// plugins via CanClaim only, so neither plugins nor claims are changed. Claims held by reservations
+// are outstanding claims to the plugins and quotas, so they count as taken. Unsatisfiable resources
+// are reported as false, while resources violating the ResourceSchema or without plugin fail like on
+// Claim.
+//
+// If ClaimerOptions.DryRunHold is set, resources reported as claimable are held for that duration,
+// so concurrent checks cannot both report true for resources only one of them gets: later checks
+// have to be satisfiable in addition to the held resources. A hold doesn't claim anything and doesn't
+// affect Claim. The first Claim, ClaimIdempotent or Reserve of the same resources and identity within
+// the hold ends it.
+func (c *claimer) CanClaimAll(ctx context.Context, resources v1alpha1.ResourceList, opts ...ClaimOption) (bool, error) {
+	if err := c.checkSchema(resources); err != nil {
+		return false, err
+	}
+
+	if err := c.checkPluginsForResources(resources); err != nil {
+		return false, errors.Join(ErrMissingPlugins, err)
+	}
+
+	claimOpts := newClaimOptions(opts)
+
+	var (
+		claimable bool
+		checkErr  error
+	)
+	if err := c.exec(ctx, func() {
+		claimable, checkErr = c.canClaimAll(resources, claimOpts)
+	}); err != nil {
+		return false, err
+	}
+
+	return claimable, checkErr
+}
+
+func (c *claimer) canClaimAll(resources v1alpha1.ResourceList, opts ClaimOptions) (bool, error) {
+	c.reapReservations()
+	c.expireHolds()
+
+	// Plugins may have been unregistered since the caller checked
+	if err := c.checkPluginsForResources(resources); err != nil {
+		return false, errors.Join(ErrMissingPlugins, err)
+	}
+
+	if err := checkQuantities(resources); err != nil {
+		return false, err
+	}
+
+	if c.checkCordoned(resources) != nil || c.checkQuota(opts.Identity, resources) != nil {
+		return false, nil
+	}
+
+	for resourceName, quantity := range resources {
+		needed, ok := c.withHeld(resourceName, quantity)
+		if !ok || !c.resources[resourceName].CanClaim(needed) {
+			return false, nil
+		}
+	}
+
+	if c.dryRunHold > 0 {
+		c.holds = append(c.holds, hold{
+			identity:  opts.Identity,
+			resources: resources.DeepCopy(),
+			expiresAt: c.clock.Now().Add(c.dryRunHold),
+		})
+	}
+	return true, nil
+}
+
+// withHeld returns the quantity of a resource that has to be claimable for a check of the given
+// quantity to succeed next to the holds. It returns false if holds or the check claim all available
+// quantity of the resource, as nothing can be claimed in addition then.
+func (c *claimer) withHeld(resourceName v1alpha1.ResourceName, quantity resource.Quantity) (resource.Quantity, bool) {
+	total := quantity.DeepCopy()
+	for _, h := range c.holds {
+		held, ok := h.resources[resourceName]
+		if !ok {
+			continue
+		}
+
+		if held.Sign() < 0 || quantity.Sign() < 0 {
+			return resource.Quantity{}, false
+		}
+		total.Add(held)
+	}
+	return total, true
+}
+
+// endHold ends the oldest hold of the resources and identity, if any.
+func (c *claimer) endHold(resources v1alpha1.ResourceList, opts ClaimOptions) {
+	c.expireHolds()
+
+	i := slices.IndexFunc(c.holds, func(h hold) bool {
+		return h.identity == opts.Identity && sameResources(h.resources, resources)
+	})
+	if i >= 0 {
+		c.holds = slices.Delete(c.holds, i, i+1)
+	}
+}
+
+// expireHolds drops the holds that expired.
+func (c *claimer) expireHolds() {
+	now := c.clock.Now()
+	c.holds = slices.DeleteFunc(c.holds, func(h hold) bool {
+		return !h.expiresAt.After(now)
+	})
+}
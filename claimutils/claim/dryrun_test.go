@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	testingclock "k8s.io/utils/clock/testing"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("CanClaimAll", func() {
+	resources := v1alpha1.ResourceList{
+		"example.com/fake":  resource.MustParse("2"),
+		"example.com/other": resource.MustParse("1"),
+	}
+
+	It("should report feasibility and hold the resources for the claim", func(ctx SpecContext) {
+		fakeClock := testingclock.NewFakeClock(time.Now())
+		fakePlugin := claimtest.NewFakePlugin("example.com/fake", 2)
+		otherPlugin := claimtest.NewFakePlugin("example.com/other", 1)
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(log.FromContext(ctx), claim.ClaimerOptions{
+			Clock:      fakeClock,
+			DryRunHold: time.Minute,
+			QuotaProvider: claim.StaticQuotas{
+				"tenant-a": v1alpha1.ResourceList{"example.com/fake": resource.MustParse("1")},
+			},
+		}, fakePlugin, otherPlugin)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("reporting satisfiable resources without claiming them")
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeTrue())
+		Expect(fakePlugin.Calls().Claim).To(BeZero())
+		Expect(otherPlugin.Calls().Claim).To(BeZero())
+
+		By("reporting held resources as unsatisfiable")
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeFalse())
+
+		By("ending the hold by claiming the resources")
+		claims, err := resourceClaimer.Claim(ctx, resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakePlugin.Calls().Claim).To(Equal(1))
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+
+		By("dropping the hold once it expired")
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeTrue())
+		fakeClock.Step(2 * time.Minute)
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeTrue())
+		fakeClock.Step(2 * time.Minute)
+
+		By("reporting resources exceeding a single plugin")
+		Expect(resourceClaimer.CanClaimAll(ctx, v1alpha1.ResourceList{
+			"example.com/fake":  resource.MustParse("3"),
+			"example.com/other": resource.MustParse("1"),
+		})).To(BeFalse())
+
+		By("reporting cordoned resources")
+		Expect(resourceClaimer.Cordon("example.com/other")).To(Succeed())
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeFalse())
+		Expect(resourceClaimer.Uncordon("example.com/other")).To(Succeed())
+
+		By("reporting resources exceeding the quota")
+		Expect(resourceClaimer.CanClaimAll(ctx, resources, claim.WithIdentity("tenant-a"))).To(BeFalse())
+
+		By("reflecting claims")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{"example.com/fake": resource.MustParse("1")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeFalse())
+
+		By("failing for unknown resources")
+		_, err = resourceClaimer.CanClaimAll(ctx, v1alpha1.ResourceList{"example.com/unknown": resource.MustParse("1")})
+		Expect(err).To(MatchError(claim.ErrMissingPlugins))
+	})
+
+	It("should not hold resources by default", func(ctx SpecContext) {
+		fakePlugin := claimtest.NewFakePlugin("example.com/fake", 2)
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			fakePlugin,
+			claimtest.NewFakePlugin("example.com/other", 1),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("reporting the same resources as claimable repeatedly")
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeTrue())
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeTrue())
+
+		By("claiming the resources checked for another identity")
+		_, err = resourceClaimer.Claim(ctx, resources, claim.WithIdentity("tenant-b"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakePlugin.Calls().Claim).To(Equal(1))
+
+		By("reporting claimed resources as unsatisfiable")
+		Expect(resourceClaimer.CanClaimAll(ctx, resources)).To(BeFalse())
+	})
+
+	It("should not report success to concurrent checks of the same resources", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{DryRunHold: time.Minute},
+			claimtest.NewFakePlugin("example.com/fake", 2),
+			claimtest.NewFakePlugin("example.com/other", 1),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			claimable int
+		)
+		for range 10 {
+			wg.Go(func() {
+				defer GinkgoRecover()
+				ok, err := resourceClaimer.CanClaimAll(ctx, resources)
+				Expect(err).NotTo(HaveOccurred())
+				if ok {
+					mu.Lock()
+					claimable++
+					mu.Unlock()
+				}
+			})
+		}
+		wg.Wait()
+		Expect(claimable).To(Equal(1))
+	})
+})
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ClaimError is the failure of the claim of a single resource. Err is ErrInsufficientResources if the
+// plugin cannot claim the requested quantity, else the error returned by the plugin.
+type ClaimError struct {
+	ResourceName v1alpha1.ResourceName
+	Requested    resource.Quantity
+	// Available is the free quantity of the resource, zero if its plugin does not report it, see
+	// CapacityPlugin and DeviceLister.
+	Available resource.Quantity
+	Err       error
+}
+
+func (e *ClaimError) Error() string {
+	return fmt.Sprintf("failed to claim %s: requested %s, available %s: %v",
+		e.ResourceName, e.Requested.String(), e.Available.String(), e.Err)
+}
+
+func (e *ClaimError) Unwrap() error {
+	return e.Err
+}
+
+// ClaimErrors is returned by Claim if the claim of one or more resources failed, ordered by resource
+// name. Use errors.As to inspect a single or all of them.
+type ClaimErrors []*ClaimError
+
+func (e ClaimErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, claimErr := range e {
+		messages = append(messages, claimErr.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e ClaimErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, claimErr := range e {
+		errs = append(errs, claimErr)
+	}
+	return errs
+}
+
+// newClaimError returns the ClaimError of the resource served by the plugin.
+func newClaimError(plugin Plugin, resourceName v1alpha1.ResourceName, requested resource.Quantity, err error) *ClaimError {
+	available, _, _ := pluginCapacity(plugin)
+	return &ClaimError{
+		ResourceName: resourceName,
+		Requested:    requested,
+		Available:    available,
+		Err:          err,
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Errors", func() {
+	var (
+		resourceClaimer interface {
+			claim.Claimer
+			Cordon(resourceName v1alpha1.ResourceName) error
+			Commit(ctx context.Context, id string) (claim.Claims, error)
+			ClaimIdempotent(ctx context.Context, requestID string, resources v1alpha1.ResourceList, opts ...claim.ClaimOption) (claim.Claims, error)
+		}
+		gpuPlugin claim.Plugin
+	)
+
+	newGPUPlugin := func(ctx context.Context) claim.Plugin {
+		plugin := gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+			devices: []pci.Address{{}, {Function: 1}},
+		}, nil)
+		Expect(plugin.Init()).To(Succeed())
+		return plugin
+	}
+
+	BeforeEach(func(ctx SpecContext) {
+		gpuPlugin = newGPUPlugin(ctx)
+
+		// failing claims despite reporting enough capacity, so claims fail in the plugin
+		failingPlugin := claimtest.NewFakePlugin("example.com/failing", 1)
+		failingPlugin.CanClaimFunc = func(resource.Quantity) bool {
+			return true
+		}
+		failingPlugin.ClaimFunc = func(resource.Quantity) (claim.ResourceClaim, error) {
+			return nil, claim.ErrInsufficientResources
+		}
+
+		var err error
+		resourceClaimer, err = claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				QuotaProvider: claim.StaticQuotas{
+					"tenant-a": v1alpha1.ResourceList{"nvidia.com/gpu": resource.MustParse("0")},
+				},
+			},
+			newGPUPlugin(ctx),
+			failingPlugin,
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		startCtx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			_ = resourceClaimer.Start(startCtx)
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+	})
+
+	claimGPUs := func(quantity string, opts ...claim.ClaimOption) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse(quantity),
+			}, opts...)
+			return err
+		}
+	}
+
+	It("should report the failed resources as ClaimErrors", func(ctx SpecContext) {
+		By("failing the claim in the claimer")
+		_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("3"),
+		})
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		var claimErrs claim.ClaimErrors
+		Expect(errors.As(err, &claimErrs)).To(BeTrue())
+		Expect(claimErrs).To(HaveLen(1))
+		Expect(claimErrs[0].ResourceName).To(Equal(v1alpha1.ResourceName("nvidia.com/gpu")))
+		Expect(claimErrs[0].Requested.Value()).To(Equal(int64(3)))
+		Expect(claimErrs[0].Available.Value()).To(Equal(int64(2)))
+
+		By("failing the claim in the plugin")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"example.com/failing": resource.MustParse("1"),
+		})
+
+		var claimErr *claim.ClaimError
+		Expect(errors.As(err, &claimErr)).To(BeTrue())
+		Expect(claimErr.ResourceName).To(Equal(v1alpha1.ResourceName("example.com/failing")))
+		Expect(claimErr.Err).To(MatchError(claim.ErrInsufficientResources))
+	})
+
+	DescribeTable("should be matchable via errors.Is",
+		func(ctx SpecContext, op func(ctx context.Context) error, sentinel error) {
+			Expect(op(ctx)).To(MatchError(sentinel))
+		},
+		Entry("claiming an unknown resource", func(ctx context.Context) error {
+			_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+				"example.com/unknown": resource.MustParse("1"),
+			})
+			return err
+		}, claim.ErrMissingPlugins),
+		Entry("releasing a claim of an unknown resource", func(ctx context.Context) error {
+			return resourceClaimer.Release(ctx, claim.Claims{"example.com/unknown": claimtest.FakeClaim{}})
+		}, claim.ErrMissingPlugins),
+		Entry("claiming from a claimer not started", func(ctx context.Context) error {
+			notStarted, err := claim.NewResourceClaimer(log.FromContext(ctx), newGPUPlugin(ctx))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = notStarted.Claim(ctx, v1alpha1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")})
+			return err
+		}, claim.ErrNotStarted),
+		Entry("starting a claimer twice", func(ctx context.Context) error {
+			return resourceClaimer.Start(ctx)
+		}, claim.ErrAlreadyStarted),
+		Entry("claiming more than the claimer can claim", claimGPUs("3"), claim.ErrInsufficientResources),
+		Entry("claiming more than the plugin can claim", func(ctx context.Context) error {
+			_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+				"example.com/failing": resource.MustParse("1"),
+			})
+			return err
+		}, claim.ErrInsufficientResources),
+		Entry("claiming more than the gpu plugin can claim", func(context.Context) error {
+			_, err := gpuPlugin.Claim(resource.MustParse("3"))
+			return err
+		}, claim.ErrInsufficientResources),
+		Entry("claiming more than the reserving plugin can claim", func(context.Context) error {
+			_, err := claim.NewReservingPlugin(gpuPlugin, 1).Claim(resource.MustParse("2"))
+			return err
+		}, claim.ErrInsufficientResources),
+		Entry("claiming beyond the quota", claimGPUs("1", claim.WithIdentity("tenant-a")), claim.ErrQuotaExceeded),
+		Entry("claiming an overflowing quantity", claimGPUs("10E"), claim.ErrQuantityOverflow),
+		Entry("claiming a cordoned resource", func(ctx context.Context) error {
+			Expect(resourceClaimer.Cordon("nvidia.com/gpu")).To(Succeed())
+			return claimGPUs("1")(ctx)
+		}, claim.ErrResourceCordoned),
+		Entry("releasing a foreign claim", func(ctx context.Context) error {
+			return resourceClaimer.Release(ctx, claim.Claims{"nvidia.com/gpu": claimtest.FakeClaim{}})
+		}, claim.ErrReleaseClaim),
+		Entry("releasing a foreign claim in the plugin", func(ctx context.Context) error {
+			return resourceClaimer.Release(ctx, claim.Claims{"nvidia.com/gpu": claimtest.FakeClaim{}})
+		}, claim.ErrInvalidResourceClaim),
+		Entry("releasing a foreign claim in the gpu plugin", func(context.Context) error {
+			return gpuPlugin.Release(claimtest.FakeClaim{})
+		}, claim.ErrInvalidResourceClaim),
+		Entry("releasing a foreign claim in the fallback plugin", func(context.Context) error {
+			return claim.NewFallbackPlugin("nvidia.com/gpu", gpuPlugin, claimtest.NewFakePlugin("example.com/fake", 1)).
+				Release(claimtest.FakeClaim{})
+		}, claim.ErrInvalidResourceClaim),
+		Entry("committing an unknown reservation", func(ctx context.Context) error {
+			_, err := resourceClaimer.Commit(ctx, "unknown")
+			return err
+		}, claim.ErrReservationNotFound),
+		Entry("claiming idempotently without request id", func(ctx context.Context) error {
+			_, err := resourceClaimer.ClaimIdempotent(ctx, "", v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("1"),
+			})
+			return err
+		}, claim.ErrEmptyRequestID),
+		Entry("claiming idempotently with a reused request id", func(ctx context.Context) error {
+			_, err := resourceClaimer.ClaimIdempotent(ctx, "request-1", v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("1"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = resourceClaimer.ClaimIdempotent(ctx, "request-1", v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("2"),
+			})
+			return err
+		}, claim.ErrRequestMismatch),
+		Entry("registering a plugin twice", func(ctx context.Context) error {
+			_, err := claim.NewResourceClaimer(log.FromContext(ctx), newGPUPlugin(ctx), newGPUPlugin(ctx))
+			return err
+		}, claim.ErrDuplicatePlugin),
+		Entry("registering a claim codec twice", func(context.Context) error {
+			registry := claim.NewRegistry()
+			Expect(registry.Register("nvidia.com/gpu", gpu.ClaimCodec{})).To(Succeed())
+			return registry.Register("nvidia.com/gpu", gpu.ClaimCodec{})
+		}, claim.ErrDuplicateClaimCodec),
+		Entry("initializing a plugin exceeding the init timeout", func(ctx context.Context) error {
+			slowPlugin := claimtest.NewFakePlugin("example.com/slow", 1)
+			slowPlugin.InitFunc = func() error {
+				time.Sleep(time.Second)
+				return nil
+			}
+			_, err := claim.NewResourceClaimerWithOptions(log.FromContext(ctx), claim.ClaimerOptions{
+				InitTimeout: 10 * time.Millisecond,
+			}, slowPlugin)
+			return err
+		}, claim.ErrPluginInitTimeout),
+	)
+})
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/eventutils/recorder"
+)
+
+const (
+	ReasonInsufficientResources = "InsufficientResources"
+	ReasonAllocated             = "Allocated"
+	ReasonClaimFailed           = "ClaimFailed"
+)
+
+// NUMAReporter is implemented by plugins reporting the NUMA nodes of the devices of their claims.
+type NUMAReporter interface {
+	Plugin
+	// ClaimNUMANodes returns the known NUMA nodes of the devices of the claim in ascending order.
+	ClaimNUMANodes(claim ResourceClaim) []int
+}
+
+// recordClaim records the outcome of a claim of a machine given via WithMachineMetadata.
+func (c *claimer) recordClaim(resources v1alpha1.ResourceList, opts ClaimOptions, claims Claims, err error) {
+	if c.eventRecorder == nil || opts.Machine == nil {
+		return
+	}
+
+	switch {
+	case errors.Is(err, ErrInsufficientResources):
+		c.eventRecorder.Eventf(
+			*opts.Machine,
+			recorder.EventTypeWarning,
+			ReasonInsufficientResources,
+			"Insufficient resources to claim %s",
+			formatResources(resources),
+		)
+	case !c.recordAllocations:
+	case err != nil:
+		c.eventRecorder.Eventf(
+			*opts.Machine,
+			recorder.EventTypeWarning,
+			ReasonClaimFailed,
+			"Failed to claim %s: %v",
+			formatResources(resources),
+			err,
+		)
+	default:
+		c.eventRecorder.Eventf(
+			*opts.Machine,
+			recorder.EventTypeNormal,
+			ReasonAllocated,
+			"Allocated %s",
+			c.formatClaims(claims),
+		)
+	}
+}
+
+// formatResources formats the resources as comma separated name=quantity pairs, sorted by name.
+func formatResources(resources v1alpha1.ResourceList) string {
+	pairs := make([]string, 0, len(resources))
+	for resourceName, quantity := range resources {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", resourceName, quantity.String()))
+	}
+	slices.Sort(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// formatClaims formats the devices and NUMA nodes of the claims, sorted by resource name. Claims not
+// implementing StatusClaim are formatted by their resource name only.
+func (c *claimer) formatClaims(claims Claims) string {
+	parts := make([]string, 0, len(claims))
+	for _, resourceName := range slices.Sorted(maps.Keys(claims)) {
+		part := string(resourceName)
+		if statusClaim, ok := UnwrapClaim(claims[resourceName]).(StatusClaim); ok {
+			part += "=" + strings.Join(statusClaim.StatusDevices(), ",")
+		}
+
+		if reporter, ok := c.resources[resourceName].(NUMAReporter); ok {
+			if nodes := reporter.ClaimNUMANodes(claims[resourceName]); len(nodes) > 0 {
+				part += " on NUMA node " + formatInts(nodes)
+			}
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+func formatInts(values []int) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, strconv.Itoa(value))
+	}
+	return strings.Join(formatted, ",")
+}
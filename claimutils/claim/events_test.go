@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	"github.com/ironcore-dev/provider-utils/eventutils/recorder"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Events", func() {
+	It("should record failed claims against the machine", func(ctx SpecContext) {
+		eventStore := recorder.NewEventStore(log.FromContext(ctx), recorder.EventStoreOptions{})
+
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				EventRecorder: eventStore,
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		machine := api.Metadata{ID: "machine-1"}
+		twoGPUs := v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		}
+
+		By("failing a claim without machine metadata")
+		_, err = resourceClaimer.Claim(ctx, twoGPUs)
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+		Expect(eventStore.ListEvents()).To(BeEmpty())
+
+		By("failing a claim for the machine")
+		_, err = resourceClaimer.Claim(ctx, twoGPUs, claim.WithMachineMetadata(machine))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		events := eventStore.ListEventsForObject("machine-1")
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Type).To(Equal(recorder.EventTypeWarning))
+		Expect(events[0].Reason).To(Equal(claim.ReasonInsufficientResources))
+		Expect(events[0].Message).To(Equal("Insufficient resources to claim nvidia.com/gpu=2"))
+
+		By("succeeding a claim for the machine")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}, claim.WithMachineMetadata(machine))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(eventStore.ListEvents()).To(HaveLen(1))
+	})
+
+	It("should record allocations and failures if enabled", func(ctx SpecContext) {
+		eventStore := recorder.NewEventStore(log.FromContext(ctx), recorder.EventStoreOptions{})
+
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				EventRecorder:     eventStore,
+				RecordAllocations: true,
+				QuotaProvider: claim.StaticQuotas{
+					"tenant-a": v1alpha1.ResourceList{"nvidia.com/gpu": resource.MustParse("0")},
+				},
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockInfoReader{
+				infos: []pci.DeviceInfo{
+					{Address: pci.Address{Bus: 0x17}, NUMANode: ptr.To(0)},
+					{Address: pci.Address{Bus: 0x18}, NUMANode: ptr.To(0)},
+					{Address: pci.Address{Bus: 0x97}, NUMANode: ptr.To(1)},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		machine := api.Metadata{ID: "machine-1"}
+
+		By("succeeding a claim for the machine")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		}, claim.WithMachineMetadata(machine), claim.WithNUMANode(0))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("failing a claim for the machine")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}, claim.WithMachineMetadata(machine), claim.WithIdentity("tenant-a"))
+		Expect(err).To(MatchError(claim.ErrQuotaExceeded))
+
+		events := eventStore.ListEventsForObject("machine-1")
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Type).To(Equal(recorder.EventTypeNormal))
+		Expect(events[0].Reason).To(Equal(claim.ReasonAllocated))
+		Expect(events[0].Message).To(Equal("Allocated nvidia.com/gpu=0000:17:00.0,0000:18:00.0 on NUMA node 0"))
+		Expect(events[1].Type).To(Equal(recorder.EventTypeWarning))
+		Expect(events[1].Reason).To(Equal(claim.ReasonClaimFailed))
+		Expect(events[1].Message).To(HavePrefix("Failed to claim nvidia.com/gpu=1: "))
+	})
+})
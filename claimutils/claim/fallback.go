@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// FallbackSource identifies the plugin of a fallback plugin holding a claim.
+type FallbackSource string
+
+const (
+	FallbackSourcePrimary   FallbackSource = "Primary"
+	FallbackSourceSecondary FallbackSource = "Secondary"
+)
+
+// FallbackClaim is the claim issued by a fallback plugin, recording the plugin it was claimed from.
+// Use UnwrapClaim to access the claim of that plugin.
+type FallbackClaim struct {
+	Source FallbackSource
+	Claim  ResourceClaim
+}
+
+// Unwrap returns the claim of the plugin the claim was claimed from.
+func (f FallbackClaim) Unwrap() ResourceClaim {
+	return f.Claim
+}
+
+// NewFallbackPlugin returns a plugin that claims from primary and falls back to secondary
+// if primary has insufficient resources. Claims are released to the plugin they were claimed from.
+// The capacity of the plugins reporting one is summed.
+func NewFallbackPlugin(name string, primary, secondary Plugin) Plugin {
+	return &fallbackPlugin{
+		name:      name,
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+type fallbackPlugin struct {
+	name      string
+	primary   Plugin
+	secondary Plugin
+}
+
+func (f *fallbackPlugin) CanClaim(quantity resource.Quantity) bool {
+	return f.primary.CanClaim(quantity) || f.secondary.CanClaim(quantity)
+}
+
+func (f *fallbackPlugin) Claim(quantity resource.Quantity) (ResourceClaim, error) {
+	primaryClaim, err := f.primary.Claim(quantity)
+	if err == nil {
+		return FallbackClaim{Source: FallbackSourcePrimary, Claim: primaryClaim}, nil
+	}
+	if !errors.Is(err, ErrInsufficientResources) {
+		return nil, err
+	}
+
+	secondaryClaim, err := f.secondary.Claim(quantity)
+	if err != nil {
+		return nil, err
+	}
+	return FallbackClaim{Source: FallbackSourceSecondary, Claim: secondaryClaim}, nil
+}
+
+func (f *fallbackPlugin) Release(claim ResourceClaim) error {
+	fallbackClaim, ok := claim.(FallbackClaim)
+	if !ok {
+		return ErrInvalidResourceClaim
+	}
+
+	switch fallbackClaim.Source {
+	case FallbackSourcePrimary:
+		return f.primary.Release(fallbackClaim.Claim)
+	case FallbackSourceSecondary:
+		return f.secondary.Release(fallbackClaim.Claim)
+	default:
+		return ErrInvalidResourceClaim
+	}
+}
+
+func (f *fallbackPlugin) capacity() (free, total resource.Quantity, ok bool) {
+	for _, plugin := range []Plugin{f.primary, f.secondary} {
+		pluginFree, pluginTotal, pluginOk := pluginCapacity(plugin)
+		if !pluginOk {
+			continue
+		}
+		free.Add(pluginFree)
+		total.Add(pluginTotal)
+		ok = true
+	}
+	return free, total, ok
+}
+
+func (f *fallbackPlugin) Init() error {
+	return errors.Join(f.primary.Init(), f.secondary.Init())
+}
+
+func (f *fallbackPlugin) Shutdown(ctx context.Context) error {
+	return errors.Join(shutdownPlugin(ctx, f.primary), shutdownPlugin(ctx, f.secondary))
+}
+
+func (f *fallbackPlugin) Name() string {
+	return f.name
+}
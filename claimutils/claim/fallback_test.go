@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"errors"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Fallback Plugin", func() {
+	var resourceClaimer claim.Claimer
+
+	oneGPU := v1alpha1.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("1"),
+	}
+
+	sourceOf := func(claims claim.Claims) claim.FallbackSource {
+		fallbackClaim, ok := claims["nvidia.com/gpu"].(claim.FallbackClaim)
+		Expect(ok).To(BeTrue())
+		return fallbackClaim.Source
+	}
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		resourceClaimer, err = claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			claim.NewFallbackPlugin(
+				"nvidia.com/gpu",
+				gpu.NewGPUClaimPlugin(log.FromContext(ctx), "local-gpu", &mockReader{
+					devices: []pci.Address{{}},
+				}, nil),
+				gpu.NewGPUClaimPlugin(log.FromContext(ctx), "remote-gpu", &mockReader{
+					devices: []pci.Address{{Bus: 1}},
+				}, nil),
+			),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should claim from the primary plugin if it satisfies the claim", func(ctx SpecContext) {
+		startClaimer(ctx, resourceClaimer)
+
+		claims, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sourceOf(claims)).To(Equal(claim.FallbackSourcePrimary))
+	})
+
+	It("should fall back to the secondary plugin and release to the holding plugin", func(ctx SpecContext) {
+		startClaimer(ctx, resourceClaimer)
+
+		primaryClaims, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("falling back once the primary plugin is exhausted")
+		secondaryClaims, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sourceOf(secondaryClaims)).To(Equal(claim.FallbackSourceSecondary))
+
+		By("releasing the primary claim to the primary plugin")
+		Expect(resourceClaimer.Release(ctx, primaryClaims)).To(Succeed())
+
+		claims, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sourceOf(claims)).To(Equal(claim.FallbackSourcePrimary))
+
+		By("releasing the secondary claim to the secondary plugin")
+		Expect(resourceClaimer.Release(ctx, secondaryClaims)).To(Succeed())
+
+		claims, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sourceOf(claims)).To(Equal(claim.FallbackSourceSecondary))
+	})
+
+	It("should expose the claim of the holding plugin", func(ctx SpecContext) {
+		startClaimer(ctx, resourceClaimer)
+
+		_, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		claims, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+
+		gpuClaim, ok := claim.UnwrapClaim(claims["nvidia.com/gpu"]).(gpu.Claim)
+		Expect(ok).To(BeTrue())
+		Expect(gpuClaim.PCIAddresses()).To(Equal([]pci.Address{{Bus: 1}}))
+
+		By("reporting the devices in the status")
+		Expect(claims.ToStatus()).To(Equal(map[string][]string{
+			"nvidia.com/gpu": {pci.Address{Bus: 1}.String()},
+		}))
+
+		By("encoding the claim of the holding plugin")
+		encoded, err := gpu.ClaimCodec{}.EncodeClaim(claims["nvidia.com/gpu"])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encoded).To(MatchJSON(`{"pciAddresses":["0000:01:00.0"]}`))
+	})
+
+	It("should fail if both plugins are exhausted", func(ctx SpecContext) {
+		startClaimer(ctx, resourceClaimer)
+
+		_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		})
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+	})
+
+	It("should not fall back if the primary plugin fails for other reasons", func() {
+		errDeviceFault := errors.New("device fault")
+		primary := claimtest.NewFakePlugin("primary", 1)
+		primary.ClaimFunc = func(resource.Quantity) (claim.ResourceClaim, error) {
+			return nil, errDeviceFault
+		}
+		secondary := claimtest.NewFakePlugin("secondary", 1)
+
+		plugin := claim.NewFallbackPlugin("nvidia.com/gpu", primary, secondary)
+		_, err := plugin.Claim(resource.MustParse("1"))
+		Expect(err).To(MatchError(errDeviceFault))
+		Expect(primary.Calls().Claim).To(Equal(1))
+		Expect(secondary.Calls().Claim).To(BeZero())
+	})
+})
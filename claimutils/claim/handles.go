@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+var (
+	ErrClaimNotFound = errors.New("claim not found")
+	ErrMixedClaimIDs = errors.New("claims of different ids")
+)
+
+// ClaimID identifies the claims issued together by a single Claim, ClaimIdempotent or Reserve call, it
+// is returned in ClaimResult.ID by ClaimWithResult and derived from the returned claims by ClaimIDOf.
+// It is opaque and only unique within a claimer. Claims restored from a snapshot keep their ID, see
+// Restore.
+type ClaimID string
+
+func claimIDOf(group uint64) ClaimID {
+	return ClaimID(strconv.FormatUint(group, 10))
+}
+
+// ListClaims returns the outstanding claims by the ID they were issued with, including the ones of
+// reservations. Claims released partially only list their remaining resources. Callers reconciling
+// after a restart can detect leaked claims by comparing them with the claims they hold.
+func (c *claimer) ListClaims(ctx context.Context) (map[ClaimID]Claims, error) {
+	var claims map[ClaimID]Claims
+	if err := c.exec(ctx, func() {
+		claims = map[ClaimID]Claims{}
+		for _, entry := range c.issued {
+			id := claimIDOf(entry.group)
+			if claims[id] == nil {
+				claims[id] = Claims{}
+			}
+			claims[id][entry.resourceName] = entry.claim
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// ClaimIDOf returns the ID the outstanding claims were issued with, e.g. ones returned by Claim, to
+// correlate them with ListClaims. It fails with ErrClaimNotFound if any of the claims is not
+// outstanding and with ErrMixedClaimIDs if they were not issued together.
+func (c *claimer) ClaimIDOf(ctx context.Context, claims Claims) (ClaimID, error) {
+	var (
+		id    ClaimID
+		idErr error
+	)
+	if err := c.exec(ctx, func() {
+		id, idErr = c.claimIDOfClaims(claims)
+	}); err != nil {
+		return "", err
+	}
+
+	return id, idErr
+}
+
+func (c *claimer) claimIDOfClaims(claims Claims) (ClaimID, error) {
+	if len(claims) == 0 {
+		return "", ErrClaimNotFound
+	}
+
+	var id ClaimID
+	for _, resourceName := range slices.Sorted(maps.Keys(claims)) {
+		i := c.issuedIndex(resourceName, claims[resourceName])
+		if i < 0 {
+			return "", fmt.Errorf("%s: %w", resourceName, ErrClaimNotFound)
+		}
+
+		entryID := claimIDOf(c.issued[i].group)
+		if id != "" && entryID != id {
+			return "", fmt.Errorf("%s: %w", resourceName, ErrMixedClaimIDs)
+		}
+		id = entryID
+	}
+	return id, nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Claim Handles", func() {
+	It("should list the outstanding claims by their id", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			claimtest.NewFakePlugin("example.com/fake", 4),
+			claimtest.NewFakePlugin("example.com/other", 4),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("claiming twice")
+		first, firstResult, err := resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"example.com/fake":  resource.MustParse("1"),
+			"example.com/other": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		second, secondResult, err := resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"example.com/fake": resource.MustParse("2"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(firstResult.ID).NotTo(BeEmpty())
+		Expect(secondResult.ID).NotTo(Equal(firstResult.ID))
+
+		_, failedResult, err := resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"example.com/fake": resource.MustParse("5"),
+		})
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+		Expect(failedResult.ID).To(BeEmpty())
+
+		By("reserving")
+		reservation, err := resourceClaimer.Reserve(ctx, v1alpha1.ResourceList{
+			"example.com/other": resource.MustParse("1"),
+		}, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+
+		claims, err := resourceClaimer.ListClaims(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims).To(HaveLen(3))
+		Expect(claims).To(HaveKeyWithValue(firstResult.ID, first))
+		Expect(claims).To(HaveKeyWithValue(secondResult.ID, second))
+		Expect(claims).To(ContainElement(reservation.Claims))
+
+		By("deriving the ids from the claims")
+		plain, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"example.com/other": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		plainID, err := resourceClaimer.ClaimIDOf(ctx, plain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceClaimer.ClaimIDOf(ctx, first)).To(Equal(firstResult.ID))
+
+		claims, err = resourceClaimer.ListClaims(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims).To(HaveKeyWithValue(plainID, plain))
+
+		_, err = resourceClaimer.ClaimIDOf(ctx, claim.Claims{
+			"example.com/fake":  second["example.com/fake"],
+			"example.com/other": first["example.com/other"],
+		})
+		Expect(err).To(MatchError(claim.ErrMixedClaimIDs))
+		_, err = resourceClaimer.ClaimIDOf(ctx, claim.Claims{
+			"example.com/fake": &claimtest.FakeClaim{Quantity: resource.MustParse("3")},
+		})
+		Expect(err).To(MatchError(claim.ErrClaimNotFound))
+		Expect(resourceClaimer.Release(ctx, plain)).To(Succeed())
+
+		By("releasing claims")
+		Expect(resourceClaimer.Release(ctx, claim.Claims{"example.com/other": first["example.com/other"]})).To(Succeed())
+		Expect(resourceClaimer.Release(ctx, second)).To(Succeed())
+
+		claims, err = resourceClaimer.ListClaims(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims).To(HaveLen(2))
+		Expect(claims).To(HaveKeyWithValue(firstResult.ID, claim.Claims{"example.com/fake": first["example.com/fake"]}))
+		Expect(claims).NotTo(HaveKey(secondResult.ID))
+	})
+})
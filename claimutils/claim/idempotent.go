@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+)
+
+var (
+	ErrEmptyRequestID  = errors.New("empty request id")
+	ErrRequestMismatch = errors.New("request id already used for different resources")
+)
+
+// ClaimIdempotent claims the given resources for the logical request identified by requestID.
+// If claims for requestID are outstanding, they are returned instead of allocating anew, so
+// retries of a request don't allocate twice. The claims are forgotten once released.
+func (c *claimer) ClaimIdempotent(
+	ctx context.Context,
+	requestID string,
+	resources v1alpha1.ResourceList,
+	opts ...ClaimOption,
+) (Claims, error) {
+	if requestID == "" {
+		return nil, ErrEmptyRequestID
+	}
+
+	if err := c.checkSchema(resources); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkPluginsForResources(resources); err != nil {
+		return nil, errors.Join(ErrMissingPlugins, err)
+	}
+
+	claimOpts := newClaimOptions(opts)
+	claimOpts.requestID = requestID
+	claimOpts.stack = c.callerStack()
+
+	var (
+		claims   Claims
+		claimErr error
+	)
+	if err := c.exec(ctx, func() {
+		if existing, existingResources := c.requestClaims(requestID); existing != nil {
+			if !sameResources(existingResources, resources) {
+				claimErr = fmt.Errorf("request %s: %w", requestID, ErrRequestMismatch)
+				return
+			}
+			claims = existing
+			return
+		}
+
+		claims, claimErr = c.claim(resources, claimOpts)
+	}); err != nil {
+		return nil, err
+	}
+
+	return claims.Clone(), claimErr
+}
+
+// requestClaims returns the outstanding claims of the given request and the resources they were claimed for,
+// nil if there are none.
+func (c *claimer) requestClaims(requestID string) (Claims, v1alpha1.ResourceList) {
+	var (
+		claims    Claims
+		resources v1alpha1.ResourceList
+	)
+	for _, entry := range c.issued {
+		if entry.requestID != requestID {
+			continue
+		}
+
+		if claims == nil {
+			claims = Claims{}
+			resources = v1alpha1.ResourceList{}
+		}
+		claims[entry.resourceName] = entry.claim
+		resources[entry.resourceName] = entry.quantity
+	}
+	return claims, resources
+}
+
+func sameResources(a, b v1alpha1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for resourceName, quantity := range a {
+		other, ok := b[resourceName]
+		if !ok || quantity.Cmp(other) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("ClaimIdempotent", func() {
+	It("should return the outstanding claims of a request", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+					{Function: 2},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		oneGPU := v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}
+
+		By("allocating on the first call")
+		first, err := resourceClaimer.ClaimIdempotent(ctx, "request-a", oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("returning the same claims on a retry")
+		retried, err := resourceClaimer.ClaimIdempotent(ctx, "request-a", oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retried).To(Equal(first))
+
+		By("rejecting a retry with different resources")
+		_, err = resourceClaimer.ClaimIdempotent(ctx, "request-a", v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		})
+		Expect(err).To(MatchError(claim.ErrRequestMismatch))
+
+		By("allocating more for a different request")
+		other, err := resourceClaimer.ClaimIdempotent(ctx, "request-b", oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other).NotTo(Equal(first))
+
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		By("allocating anew once the claims of a request got released")
+		Expect(resourceClaimer.Release(ctx, first)).To(Succeed())
+		again, err := resourceClaimer.ClaimIdempotent(ctx, "request-a", oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(Equal(first))
+
+		_, err = resourceClaimer.ClaimIdempotent(ctx, "request-c", oneGPU)
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+	})
+})
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// maxClaimStackDepth is the maximum number of frames captured per claim stack.
+const maxClaimStackDepth = 32
+
+// IssuedClaim describes an outstanding claim of a single resource.
+type IssuedClaim struct {
+	// ID identifies the claims issued together with this one, see ListClaims.
+	ID       ClaimID
+	Resource v1alpha1.ResourceName
+	Claim    ResourceClaim
+	Identity string
+	Quantity resource.Quantity
+	Priority int32
+	// Stack is the stack of the caller of the claim, empty unless ClaimerOptions.CaptureClaimStacks is set.
+	Stack string
+}
+
+// ListIssued returns all outstanding claims, including the ones of reservations.
+func (c *claimer) ListIssued(ctx context.Context) ([]IssuedClaim, error) {
+	var issued []IssuedClaim
+	if err := c.exec(ctx, func() {
+		issued = make([]IssuedClaim, 0, len(c.issued))
+		for _, entry := range c.issued {
+			issued = append(issued, IssuedClaim{
+				ID:       claimIDOf(entry.group),
+				Resource: entry.resourceName,
+				Claim:    entry.claim,
+				Identity: entry.identity,
+				Quantity: entry.quantity.DeepCopy(),
+				Priority: entry.priority,
+				Stack:    formatStack(entry.stack),
+			})
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return issued, nil
+}
+
+// callerStack returns the program counters of the caller of the public claim method calling it, nil
+// unless ClaimerOptions.CaptureClaimStacks is set. It has to be called by that method directly.
+func (c *claimer) callerStack() []uintptr {
+	if !c.captureClaimStacks {
+		return nil
+	}
+
+	// Skip runtime.Callers, callerStack and the public claim method
+	pcs := make([]uintptr, maxClaimStackDepth)
+	return pcs[:runtime.Callers(3, pcs)]
+}
+
+func formatStack(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		_, _ = fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Introspection", func() {
+	oneGPU := v1alpha1.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("1"),
+	}
+
+	// callerFile returns the file of the first frame of a stack
+	callerFile := func(stack string) string {
+		lines := strings.SplitN(stack, "\n", 3)
+		if len(lines) < 2 {
+			return ""
+		}
+		return lines[1]
+	}
+
+	newClaimer := func(ctx SpecContext, captureStacks bool) interface {
+		claim.Claimer
+		ClaimWithResult(ctx context.Context, resources v1alpha1.ResourceList, opts ...claim.ClaimOption) (claim.Claims, claim.ClaimResult, error)
+		ClaimIdempotent(ctx context.Context, requestID string, resources v1alpha1.ResourceList, opts ...claim.ClaimOption) (claim.Claims, error)
+		Reserve(ctx context.Context, resources v1alpha1.ResourceList, ttl time.Duration, opts ...claim.ClaimOption) (claim.Reservation, error)
+		ListIssued(ctx context.Context) ([]claim.IssuedClaim, error)
+	} {
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				CaptureClaimStacks: captureStacks,
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}, {Function: 1}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+		return resourceClaimer
+	}
+
+	It("should record the stack of the claim caller if enabled", func(ctx SpecContext) {
+		resourceClaimer := newClaimer(ctx, true)
+
+		claims, err := resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("tenant-a"))
+		Expect(err).NotTo(HaveOccurred())
+
+		issued, err := resourceClaimer.ListIssued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued).To(ConsistOf(SatisfyAll(
+			HaveField("Resource", v1alpha1.ResourceName("nvidia.com/gpu")),
+			HaveField("Claim", claims["nvidia.com/gpu"]),
+			HaveField("Identity", "tenant-a"),
+			HaveField("Stack", WithTransform(callerFile, ContainSubstring("introspection_test.go"))),
+		)))
+
+		By("forgetting released claims")
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+		Expect(resourceClaimer.ListIssued(ctx)).To(BeEmpty())
+	})
+
+	It("should record the stack starting at the caller of every claim method", func(ctx SpecContext) {
+		resourceClaimer := newClaimer(ctx, true)
+
+		By("claiming with result")
+		claims, _, err := resourceClaimer.ClaimWithResult(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		issued, err := resourceClaimer.ListIssued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued).To(ConsistOf(HaveField("Stack", WithTransform(callerFile, ContainSubstring("introspection_test.go")))))
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+
+		By("claiming idempotently")
+		claims, err = resourceClaimer.ClaimIdempotent(ctx, "request-1", oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		issued, err = resourceClaimer.ListIssued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued).To(ConsistOf(HaveField("Stack", WithTransform(callerFile, ContainSubstring("introspection_test.go")))))
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+
+		By("reserving")
+		_, err = resourceClaimer.Reserve(ctx, oneGPU, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		issued, err = resourceClaimer.ListIssued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued).To(ConsistOf(HaveField("Stack", WithTransform(callerFile, ContainSubstring("introspection_test.go")))))
+	})
+
+	It("should not record stacks by default", func(ctx SpecContext) {
+		resourceClaimer := newClaimer(ctx, false)
+
+		_, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+
+		issued, err := resourceClaimer.ListIssued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued).To(ConsistOf(HaveField("Stack", BeEmpty())))
+	})
+})
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"reflect"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// issuedClaim is an outstanding claim of a single resource handed out by the claimer.
+type issuedClaim struct {
+	resourceName v1alpha1.ResourceName
+	claim        ResourceClaim
+	identity     string
+	quantity     resource.Quantity
+	// claimed is the quantity actually granted, differing from quantity for sentinels like gpu.AllAvailable.
+	claimed   resource.Quantity
+	priority  int32
+	requestID string
+	stack     []uintptr
+	// antiAffinityKey is the key later claims avoid the placement of this one by.
+	antiAffinityKey string
+	// group identifies the claims issued together by a single Claim or Reserve call.
+	group uint64
+}
+
+func (c *claimer) recordIssued(opts ClaimOptions, resources v1alpha1.ResourceList, claims Claims) {
+	c.nextIssuedGroup++
+	for resourceName, resourceClaim := range claims {
+		c.issued = append(c.issued, issuedClaim{
+			resourceName: resourceName,
+			claim:        resourceClaim,
+			identity:     opts.Identity,
+			quantity:     resources[resourceName],
+			claimed:      claimedQuantity(resources[resourceName], resourceClaim),
+			priority:     opts.Priority,
+			requestID:    opts.requestID,
+			stack:        opts.stack,
+			group:        c.nextIssuedGroup,
+
+			antiAffinityKey: opts.AntiAffinityKey,
+		})
+	}
+}
+
+// claimedQuantity returns the quantity granted by a claim of the requested quantity. Negative sentinel
+// quantities count the devices of the claim if it implements StatusClaim, else nothing.
+func claimedQuantity(requested resource.Quantity, resourceClaim ResourceClaim) resource.Quantity {
+	if requested.Sign() >= 0 {
+		return requested
+	}
+
+	statusClaim, ok := UnwrapClaim(resourceClaim).(StatusClaim)
+	if !ok {
+		return resource.Quantity{}
+	}
+	return *resource.NewQuantity(int64(len(statusClaim.StatusDevices())), resource.DecimalSI)
+}
+
+func (c *claimer) forgetIssued(claims Claims) {
+	for resourceName, resourceClaim := range claims {
+		if i := c.issuedIndex(resourceName, resourceClaim); i >= 0 {
+			c.issued = append(c.issued[:i], c.issued[i+1:]...)
+		}
+	}
+}
+
+// issuedIndex returns the index of the issued claim matching the given one, preferring identical
+// claims over deeply equal ones, e.g. decoded from a snapshot. It returns -1 if none matches.
+func (c *claimer) issuedIndex(resourceName v1alpha1.ResourceName, resourceClaim ResourceClaim) int {
+	equal := -1
+	for i, entry := range c.issued {
+		if entry.resourceName != resourceName {
+			continue
+		}
+
+		if identicalClaim(entry.claim, resourceClaim) {
+			return i
+		}
+		if equal < 0 && reflect.DeepEqual(entry.claim, resourceClaim) {
+			equal = i
+		}
+	}
+	return equal
+}
+
+// identicalClaim reports whether a and b are the identical claim. Claims of non-comparable types are never identical.
+func identicalClaim(a, b ResourceClaim) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if reflect.TypeOf(a).Comparable() && reflect.TypeOf(b).Comparable() {
+		return a == b
+	}
+
+	return false
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NewLazyPlugin returns a plugin that defers creating and initializing the actual plugin
+// until it is first used. A successfully initialized plugin is cached, a failed creation
+// or initialization is retried on the next use. The capacity of the actual plugin is reported once it
+// was created.
+func NewLazyPlugin(name string, factory func() (Plugin, error)) Plugin {
+	return &lazyPlugin{
+		name:    name,
+		factory: factory,
+	}
+}
+
+type lazyPlugin struct {
+	name    string
+	factory func() (Plugin, error)
+	plugin  Plugin
+}
+
+func (l *lazyPlugin) get() (Plugin, error) {
+	if l.plugin != nil {
+		return l.plugin, nil
+	}
+
+	plugin, err := l.factory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin %s: %w", l.name, err)
+	}
+
+	if err := plugin.Init(); err != nil {
+		return nil, fmt.Errorf("failed to init plugin %s: %w", l.name, err)
+	}
+
+	l.plugin = plugin
+	return plugin, nil
+}
+
+func (l *lazyPlugin) CanClaim(quantity resource.Quantity) bool {
+	plugin, err := l.get()
+	if err != nil {
+		return false
+	}
+	return plugin.CanClaim(quantity)
+}
+
+func (l *lazyPlugin) Claim(quantity resource.Quantity) (ResourceClaim, error) {
+	plugin, err := l.get()
+	if err != nil {
+		return nil, err
+	}
+	return plugin.Claim(quantity)
+}
+
+func (l *lazyPlugin) Release(claim ResourceClaim) error {
+	if l.plugin == nil {
+		return ErrInvalidResourceClaim
+	}
+	return l.plugin.Release(claim)
+}
+
+func (l *lazyPlugin) RestoreClaim(claim ResourceClaim) error {
+	plugin, err := l.get()
+	if err != nil {
+		return err
+	}
+
+	restorablePlugin, ok := plugin.(RestorablePlugin)
+	if !ok {
+		return ErrRestoreNotSupported
+	}
+	return restorablePlugin.RestoreClaim(claim)
+}
+
+func (l *lazyPlugin) capacity() (free, total resource.Quantity, ok bool) {
+	if l.plugin == nil {
+		return resource.Quantity{}, resource.Quantity{}, false
+	}
+	return pluginCapacity(l.plugin)
+}
+
+func (l *lazyPlugin) wrapped() Plugin {
+	return l.plugin
+}
+
+// Init does not initialize the actual plugin, this is deferred until the first use.
+func (l *lazyPlugin) Init() error {
+	return nil
+}
+
+// Shutdown shuts down the actual plugin if it was created.
+func (l *lazyPlugin) Shutdown(ctx context.Context) error {
+	if l.plugin == nil {
+		return nil
+	}
+	return shutdownPlugin(ctx, l.plugin)
+}
+
+func (l *lazyPlugin) Name() string {
+	return l.name
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Lazy Plugin", func() {
+	It("should create and init the plugin on first claim only", func(ctx SpecContext) {
+		var factoryCalls int
+		lazyPlugin := claim.NewLazyPlugin("nvidia.com/gpu", func() (claim.Plugin, error) {
+			factoryCalls++
+			return gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}, {Function: 1}},
+			}, nil), nil
+		})
+
+		By("constructing the claimer")
+		resourceClaimer, err := claim.NewResourceClaimer(log.FromContext(ctx), lazyPlugin)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+		Expect(factoryCalls).To(BeZero())
+
+		By("claiming for the first time")
+		claims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(factoryCalls).To(Equal(1))
+
+		By("claiming and releasing again")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+		Expect(factoryCalls).To(Equal(1))
+	})
+})
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+// ClaimerMiddleware wraps a Claimer to add behavior around its methods, e.g. auditing or metrics.
+// Middlewares typically embed next and override Claim and Release.
+type ClaimerMiddleware func(next Claimer) Claimer
+
+// Chain wraps base with the given middlewares. The first middleware is the outermost one,
+// i.e. it is called first and calls the second one.
+func Chain(base Claimer, mw ...ClaimerMiddleware) Claimer {
+	claimer := base
+	for i := len(mw) - 1; i >= 0; i-- {
+		claimer = mw[i](claimer)
+	}
+	return claimer
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type countingClaimer struct {
+	claim.Claimer
+	name  string
+	calls *[]string
+}
+
+func (c *countingClaimer) Claim(
+	ctx context.Context,
+	resources v1alpha1.ResourceList,
+	opts ...claim.ClaimOption,
+) (claim.Claims, error) {
+	*c.calls = append(*c.calls, c.name+":claim")
+	return c.Claimer.Claim(ctx, resources, opts...)
+}
+
+func (c *countingClaimer) Release(ctx context.Context, claims claim.Claims) error {
+	*c.calls = append(*c.calls, c.name+":release")
+	return c.Claimer.Release(ctx, claims)
+}
+
+func countingMiddleware(name string, calls *[]string) claim.ClaimerMiddleware {
+	return func(next claim.Claimer) claim.Claimer {
+		return &countingClaimer{Claimer: next, name: name, calls: calls}
+	}
+}
+
+var _ = Describe("Middleware", func() {
+	It("should call the middlewares in order around the claimer", func(ctx SpecContext) {
+		base, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		var calls []string
+		resourceClaimer := claim.Chain(base,
+			countingMiddleware("outer", &calls),
+			countingMiddleware("inner", &calls),
+		)
+		startClaimer(ctx, resourceClaimer)
+
+		claims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+
+		Expect(calls).To(Equal([]string{"outer:claim", "inner:claim", "outer:release", "inner:release"}))
+	})
+})
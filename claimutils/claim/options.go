@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import "github.com/ironcore-dev/provider-utils/apiutils/api"
+
+// ClaimOptions are the options of a single claim.
+type ClaimOptions struct {
+	// Identity identifies the requester, e.g. a tenant, and is used for quota accounting.
+	Identity string
+	// Priority is used to decide which claims may be preempted in favor of this one.
+	Priority int32
+	// Machine, if set, is the machine the claim is made for. Failed claims are recorded as its events.
+	Machine *api.Metadata
+	// NUMANode, if set, is the NUMA node whose devices are preferred by plugins supporting it.
+	NUMANode *int
+	// AntiAffinityKey, if set, makes plugins supporting it avoid co-locating the claim with outstanding
+	// claims of the same key.
+	AntiAffinityKey string
+	// Sticky, if set, holds previous claims whose devices are preferred by plugins supporting it.
+	Sticky Claims
+
+	requestID string
+	stack     []uintptr
+	// result collects the diagnostics of the claim on the claimer loop.
+	result *ClaimResult
+}
+
+// ClaimOption configures a single claim.
+type ClaimOption func(o *ClaimOptions)
+
+// WithIdentity sets the identity of the requester.
+func WithIdentity(identity string) ClaimOption {
+	return func(o *ClaimOptions) {
+		o.Identity = identity
+	}
+}
+
+// WithPriority sets the priority of the claim. Claims may preempt claims of lower priority.
+func WithPriority(priority int32) ClaimOption {
+	return func(o *ClaimOptions) {
+		o.Priority = priority
+	}
+}
+
+// WithMachineMetadata sets the machine the claim is made for.
+func WithMachineMetadata(machine api.Metadata) ClaimOption {
+	return func(o *ClaimOptions) {
+		o.Machine = &machine
+	}
+}
+
+// WithNUMANode prefers devices of the given NUMA node. Plugins fall back to other nodes if
+// the node cannot satisfy the claim, which is reported as a warning by ClaimWithResult.
+func WithNUMANode(node int) ClaimOption {
+	return func(o *ClaimOptions) {
+		o.NUMANode = &node
+	}
+}
+
+// WithAntiAffinity avoids co-locating the claim with outstanding claims of the same key, e.g. on the
+// same NUMA node. Plugins fall back to co-located devices if there are too few others, which is
+// reported as a warning by ClaimWithResult. Anti-affinity takes precedence over WithNUMANode.
+func WithAntiAffinity(key string) ClaimOption {
+	return func(o *ClaimOptions) {
+		o.AntiAffinityKey = key
+	}
+}
+
+// WithStickyClaims prefers the devices of previous claims, e.g. of a restarted machine, if they are
+// still free. Plugins claim other devices otherwise, which is reported as a warning by ClaimWithResult.
+func WithStickyClaims(previous Claims) ClaimOption {
+	return func(o *ClaimOptions) {
+		o.Sticky = previous
+	}
+}
+
+func newClaimOptions(opts []ClaimOption) ClaimOptions {
+	o := ClaimOptions{result: &ClaimResult{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	ErrQuantityOverflow = errors.New("quantity overflows int64")
+)
+
+var (
+	maxInt64Quantity = *resource.NewQuantity(math.MaxInt64, resource.DecimalSI)
+	minInt64Quantity = *resource.NewQuantity(math.MinInt64, resource.DecimalSI)
+)
+
+// Int64Value returns the value of the quantity like Quantity.Value, which silently wraps quantities
+// beyond the int64 range. It fails with ErrQuantityOverflow for those instead.
+func Int64Value(quantity resource.Quantity) (int64, error) {
+	if quantity.Cmp(maxInt64Quantity) > 0 || quantity.Cmp(minInt64Quantity) < 0 {
+		return 0, fmt.Errorf("%s: %w", quantity.String(), ErrQuantityOverflow)
+	}
+	return quantity.Value(), nil
+}
+
+// AddInt64 returns the sum of a and b, failing with ErrQuantityOverflow if it exceeds the int64 range.
+func AddInt64(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, fmt.Errorf("%d + %d: %w", a, b, ErrQuantityOverflow)
+	}
+	return a + b, nil
+}
+
+// checkQuantities fails with ErrQuantityOverflow if a requested quantity exceeds the int64 range
+// plugins compute with.
+func checkQuantities(resources v1alpha1.ResourceList) error {
+	var overflowErrors []error
+	for resourceName, quantity := range resources {
+		if _, err := Int64Value(quantity); err != nil {
+			overflowErrors = append(overflowErrors, fmt.Errorf("%s: %w", resourceName, err))
+		}
+	}
+	return errors.Join(overflowErrors...)
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"math"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Overflow", func() {
+	It("should detect int64 overflows", func() {
+		_, err := claim.AddInt64(math.MaxInt64-1, 2)
+		Expect(err).To(MatchError(claim.ErrQuantityOverflow))
+		_, err = claim.AddInt64(math.MinInt64+1, -2)
+		Expect(err).To(MatchError(claim.ErrQuantityOverflow))
+		Expect(claim.AddInt64(math.MaxInt64-1, 1)).To(Equal(int64(math.MaxInt64)))
+
+		quantity := *resource.NewQuantity(math.MaxInt64, resource.DecimalSI)
+		Expect(claim.Int64Value(quantity)).To(Equal(int64(math.MaxInt64)))
+		quantity.Add(resource.MustParse("1"))
+		_, err = claim.Int64Value(quantity)
+		Expect(err).To(MatchError(claim.ErrQuantityOverflow))
+	})
+
+	It("should refuse quantities overflowing when summed with the reserve", func(ctx SpecContext) {
+		plugin := gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+			devices: []pci.Address{{}},
+		}, nil)
+		Expect(plugin.Init()).To(Succeed())
+		reserving := claim.NewReservingPlugin(plugin, 10)
+
+		Expect(reserving.CanClaim(*resource.NewQuantity(math.MaxInt64-5, resource.DecimalSI))).To(BeFalse())
+		_, err := reserving.Claim(*resource.NewQuantity(math.MaxInt64-5, resource.DecimalSI))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+	})
+
+	It("should fail claims of quantities beyond int64", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		huge := *resource.NewQuantity(math.MaxInt64, resource.DecimalSI)
+		huge.Add(resource.MustParse("10"))
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": huge,
+		})
+		Expect(err).To(MatchError(claim.ErrQuantityOverflow))
+	})
+})
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/storeutils/store"
+)
+
+// MachineClaims is the stored form of the claims of a machine, its ID is the id of the machine.
+// Claims are encoded with the codecs of a Registry.
+type MachineClaims struct {
+	api.Metadata `json:"metadata,omitempty"`
+
+	Claims map[v1alpha1.ResourceName]json.RawMessage `json:"claims,omitempty"`
+}
+
+// SaveMachineClaims stores the claims of the machine, creating its MachineClaims if none are stored
+// yet. Concurrent saves of the same machine fail with store.ErrResourceVersionNotLatest.
+func SaveMachineClaims(
+	ctx context.Context,
+	s store.Store[*MachineClaims],
+	registry *Registry,
+	machineID string,
+	claims Claims,
+) (*MachineClaims, error) {
+	encoded, err := registry.Encode(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.Get(ctx, machineID)
+	if errors.Is(err, store.ErrNotFound) {
+		created, err := s.Create(ctx, &MachineClaims{
+			Metadata: api.Metadata{ID: machineID},
+			Claims:   encoded,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create claims of machine %s: %w", machineID, err)
+		}
+		return created, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claims of machine %s: %w", machineID, err)
+	}
+
+	stored.Claims = encoded
+	updated, err := s.Update(ctx, stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update claims of machine %s: %w", machineID, err)
+	}
+	return updated, nil
+}
+
+// LoadMachineClaims loads the claims of the machine, failing with store.ErrNotFound if none are stored.
+func LoadMachineClaims(
+	ctx context.Context,
+	s store.Store[*MachineClaims],
+	registry *Registry,
+	machineID string,
+) (Claims, error) {
+	stored, err := s.Get(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claims of machine %s: %w", machineID, err)
+	}
+
+	return registry.Decode(stored.Claims)
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	"github.com/ironcore-dev/provider-utils/storeutils/host"
+	"github.com/ironcore-dev/provider-utils/storeutils/store"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Machine Claims Persistence", func() {
+	It("should save and load the claims of a machine", func(ctx SpecContext) {
+		registry := claim.NewRegistry()
+		Expect(registry.Register("nvidia.com/gpu", gpu.ClaimCodec{})).To(Succeed())
+
+		claimStore, err := host.NewStore(host.Options[*claim.MachineClaims]{
+			Dir: GinkgoT().TempDir(),
+			NewFunc: func() *claim.MachineClaims {
+				return &claim.MachineClaims{}
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{Bus: 0x17}, {Bus: 0x97}, {Bus: 0xca}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("loading the claims of a machine without stored claims")
+		_, err = claim.LoadMachineClaims(ctx, claimStore, registry, "machine-1")
+		Expect(err).To(MatchError(store.ErrNotFound))
+
+		By("saving the claims of the machine")
+		claims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = claim.SaveMachineClaims(ctx, claimStore, registry, "machine-1", claims)
+		Expect(err).NotTo(HaveOccurred())
+
+		loaded, err := claim.LoadMachineClaims(ctx, claimStore, registry, "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(HaveKey(v1alpha1.ResourceName("nvidia.com/gpu")))
+		Expect(loaded["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).
+			To(Equal(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()))
+
+		By("overwriting the claims of the machine")
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+		claims, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("3"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		saved, err := claim.SaveMachineClaims(ctx, claimStore, registry, "machine-1", claims)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(saved.ID).To(Equal("machine-1"))
+
+		loaded, err = claim.LoadMachineClaims(ctx, claimStore, registry, "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(HaveLen(3))
+	})
+})
@@ -5,13 +5,20 @@ package claim
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// Errors of plugins and the claimer wrap the sentinels of this package, callers match them via
+// errors.Is instead of comparing errors.
 var (
 	ErrInsufficientResources = errors.New("insufficient resources")
 	ErrInvalidResourceClaim  = errors.New("invalid resource claim")
+	ErrDuplicatePlugin       = errors.New("duplicate plugin")
+	ErrDuplicateResource     = errors.New("duplicate resource")
+	ErrOverlappingDevices    = errors.New("overlapping devices")
 )
 
 type Plugin interface {
@@ -23,3 +30,46 @@ type Plugin interface {
 }
 
 type ResourceClaim interface{}
+
+// UnwrapClaim returns the claim wrapped by claims implementing Unwrap() ResourceClaim, e.g. a
+// FallbackClaim, repeatedly, else the claim itself. Type assertions on claims returned by the claimer
+// should be made on the unwrapped claim.
+func UnwrapClaim(resourceClaim ResourceClaim) ResourceClaim {
+	for {
+		wrapper, ok := resourceClaim.(interface{ Unwrap() ResourceClaim })
+		if !ok {
+			return resourceClaim
+		}
+		resourceClaim = wrapper.Unwrap()
+	}
+}
+
+// ResourcePlugin is implemented by plugins serving a resource that differs from their name.
+// Plugins not implementing it serve the resource matching their name.
+type ResourcePlugin interface {
+	Plugin
+	ResourceName() v1alpha1.ResourceName
+}
+
+// ResourceNameOf returns the resource served by the given plugin.
+func ResourceNameOf(plugin Plugin) v1alpha1.ResourceName {
+	if resourcePlugin, ok := plugin.(ResourcePlugin); ok {
+		return resourcePlugin.ResourceName()
+	}
+	return v1alpha1.ResourceName(plugin.Name())
+}
+
+// RegistrationError is returned when a plugin cannot be registered. Err is either ErrDuplicatePlugin,
+// ErrDuplicateResource or ErrOverlappingDevices, Identifier the offending plugin, resource or device.
+type RegistrationError struct {
+	Identifier string
+	Err        error
+}
+
+func (e *RegistrationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Err, e.Identifier)
+}
+
+func (e *RegistrationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import "k8s.io/apimachinery/pkg/api/resource"
+
+// SelectionPolicy is the claimer-wide policy plugins select devices by.
+type SelectionPolicy string
+
+const (
+	// SelectionPolicyDefault leaves the selection to the plugin.
+	SelectionPolicyDefault SelectionPolicy = ""
+	// SelectionPolicyPack consolidates claims on as few device groups as possible, e.g. for power savings.
+	SelectionPolicyPack SelectionPolicy = "Pack"
+	// SelectionPolicySpread distributes claims across device groups, e.g. for resilience.
+	SelectionPolicySpread SelectionPolicy = "Spread"
+	// SelectionPolicyLeastLoaded prefers the least utilized and coolest devices, as far as the plugin
+	// knows their telemetry.
+	SelectionPolicyLeastLoaded SelectionPolicy = "LeastLoaded"
+)
+
+// PolicyAware is implemented by plugins honoring the selection policy of the claimer.
+type PolicyAware interface {
+	Plugin
+	// ClaimWithPolicy claims like Claim, selecting devices according to the given policy.
+	ClaimWithPolicy(quantity resource.Quantity, policy SelectionPolicy) (ResourceClaim, error)
+}
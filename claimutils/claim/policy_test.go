@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Selection Policies", func() {
+	DescribeTable("should select devices according to the policy",
+		func(ctx SpecContext, policy claim.SelectionPolicy, expected []pci.Address) {
+			resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+				log.FromContext(ctx),
+				claim.ClaimerOptions{SelectionPolicy: policy},
+				gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockInfoReader{
+					infos: []pci.DeviceInfo{
+						{Address: pci.Address{Bus: 0x17}, NUMANode: ptr.To(0)},
+						{Address: pci.Address{Bus: 0x18}, NUMANode: ptr.To(0)},
+						{Address: pci.Address{Bus: 0x97}, NUMANode: ptr.To(1)},
+						{Address: pci.Address{Bus: 0x98}, NUMANode: ptr.To(1)},
+					},
+				}, nil),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			startClaimer(ctx, resourceClaimer)
+
+			claims, result, err := resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("2"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Strategies).To(HaveKeyWithValue(
+				v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategySelectionPolicy,
+			))
+			Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal(expected))
+		},
+		Entry("pack", claim.SelectionPolicyPack, []pci.Address{{Bus: 0x17}, {Bus: 0x18}}),
+		Entry("spread", claim.SelectionPolicySpread, []pci.Address{{Bus: 0x17}, {Bus: 0x97}}),
+	)
+
+	DescribeTable("should pack onto the smallest group satisfying the claim",
+		func(ctx SpecContext, quantity string, expected []pci.Address) {
+			resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+				log.FromContext(ctx),
+				claim.ClaimerOptions{SelectionPolicy: claim.SelectionPolicyPack},
+				gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockInfoReader{
+					infos: []pci.DeviceInfo{
+						{Address: pci.Address{Bus: 0x17}, NUMANode: ptr.To(0)},
+						{Address: pci.Address{Bus: 0x97}, NUMANode: ptr.To(1)},
+						{Address: pci.Address{Bus: 0x98}, NUMANode: ptr.To(1)},
+						{Address: pci.Address{Bus: 0x99}, NUMANode: ptr.To(1)},
+					},
+				}, nil),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			startClaimer(ctx, resourceClaimer)
+
+			claims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse(quantity),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal(expected))
+		},
+		Entry("fitting the smaller group", "1", []pci.Address{{Bus: 0x17}}),
+		Entry("fitting only the larger group", "2", []pci.Address{{Bus: 0x97}, {Bus: 0x98}}),
+		Entry("splitting from the largest group if no group fits", "4", []pci.Address{{Bus: 0x97}, {Bus: 0x98}, {Bus: 0x99}, {Bus: 0x17}}),
+	)
+})
+
+type fakeTelemetrySource map[pci.Address]pci.Telemetry
+
+func (f fakeTelemetrySource) Telemetry(address pci.Address) (pci.Telemetry, error) {
+	telemetry, ok := f[address]
+	if !ok {
+		return pci.Telemetry{}, pci.ErrNoTelemetry
+	}
+	return telemetry, nil
+}
+
+var _ = Describe("Least Loaded Selection Policy", func() {
+	It("should select the coolest of equally utilized devices", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{SelectionPolicy: claim.SelectionPolicyLeastLoaded},
+			gpu.NewGPUClaimPluginWithOptions(log.FromContext(ctx), "nvidia.com/gpu", &mockInfoReader{
+				infos: []pci.DeviceInfo{
+					{Address: pci.Address{Bus: 0x17}},
+					{Address: pci.Address{Bus: 0x18}},
+					{Address: pci.Address{Bus: 0x97}},
+				},
+			}, nil, gpu.Options{
+				TelemetrySource: fakeTelemetrySource{
+					{Bus: 0x17}: {UtilizationPercent: 0, TemperatureC: 71},
+					{Bus: 0x18}: {UtilizationPercent: 0, TemperatureC: 38},
+				},
+			}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		claims, result, err := resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Strategies).To(HaveKeyWithValue(
+			v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategySelectionPolicy,
+		))
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x18}}))
+
+		By("falling back to devices without telemetry last")
+		claims, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x17}, {Bus: 0x97}}))
+	})
+})
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+)
+
+// PreemptionCandidate is a set of claims issued by a single Claim call that may be preempted.
+type PreemptionCandidate struct {
+	Claims   Claims
+	Identity string
+	Priority int32
+
+	group uint64
+}
+
+// Preemptor decides which claims to preempt when a claim of the given priority cannot be satisfied.
+// The candidates hold at least one of the requested resources, have a lower priority than the request
+// and are ordered by ascending priority. The returned candidates are released by the claimer before
+// retrying the claim, their holders must not release them again. Candidates that cannot make room for
+// the claim together are not released, and released ones are claimed again if the retry fails.
+type Preemptor interface {
+	Preempt(resources v1alpha1.ResourceList, priority int32, candidates []PreemptionCandidate) []PreemptionCandidate
+}
+
+// PreemptorFunc is a function implementing Preemptor.
+type PreemptorFunc func(resources v1alpha1.ResourceList, priority int32, candidates []PreemptionCandidate) []PreemptionCandidate
+
+func (f PreemptorFunc) Preempt(
+	resources v1alpha1.ResourceList,
+	priority int32,
+	candidates []PreemptionCandidate,
+) []PreemptionCandidate {
+	return f(resources, priority, candidates)
+}
+
+// preempt releases the claims selected by the preemptor and returns their issued claims, nil if none
+// got released. Victims are only released if the free quantity of the resources reporting their
+// capacity covers the request once they are.
+func (c *claimer) preempt(resources v1alpha1.ResourceList, opts ClaimOptions) []issuedClaim {
+	if c.preemptor == nil {
+		return nil
+	}
+
+	candidates := c.preemptionCandidates(resources, opts.Priority)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	offered := make([]PreemptionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidate.Claims = candidate.Claims.Clone()
+		offered = append(offered, candidate)
+	}
+
+	var victims []PreemptionCandidate
+	for _, victim := range c.preemptor.Preempt(resources, opts.Priority, offered) {
+		idx := slices.IndexFunc(candidates, func(candidate PreemptionCandidate) bool {
+			return candidate.group == victim.group
+		})
+		if idx < 0 {
+			continue
+		}
+
+		victims = append(victims, candidates[idx])
+		candidates = slices.Delete(candidates, idx, idx+1)
+	}
+	if len(victims) == 0 || !c.preemptionCovers(resources, victims) {
+		return nil
+	}
+
+	var preempted []issuedClaim
+	for _, victim := range victims {
+		for resourceName, resourceClaim := range victim.Claims {
+			if i := c.issuedIndex(resourceName, resourceClaim); i >= 0 {
+				preempted = append(preempted, c.issued[i])
+			}
+		}
+
+		c.log.V(1).Info("Preempting claims", "identity", victim.Identity, "priority", victim.Priority)
+		if err := c.release(victim.Claims); err != nil {
+			c.log.Error(errors.Join(ErrReleaseClaim, err), "failed to release preempted claims")
+		}
+	}
+
+	return preempted
+}
+
+// preemptionCovers reports whether the free quantity of the requested resources and the quantity the
+// victims hold of them cover the request. Resources whose plugin doesn't report its capacity and
+// sentinel quantities are assumed to be covered.
+func (c *claimer) preemptionCovers(resources v1alpha1.ResourceList, victims []PreemptionCandidate) bool {
+	for resourceName, quantity := range resources {
+		if quantity.Sign() < 0 {
+			continue
+		}
+
+		free, _, ok := pluginCapacity(c.resources[resourceName])
+		if !ok {
+			continue
+		}
+
+		for _, victim := range victims {
+			resourceClaim, ok := victim.Claims[resourceName]
+			if !ok {
+				continue
+			}
+			if i := c.issuedIndex(resourceName, resourceClaim); i >= 0 {
+				free.Add(c.issued[i].claimed)
+			}
+		}
+		if free.Cmp(quantity) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// restorePreempted marks the preempted claims as claimed again after the claim they were preempted for
+// failed anyway. Claims of plugins not implementing RestorablePlugin stay released.
+func (c *claimer) restorePreempted(preempted []issuedClaim) {
+	for _, entry := range preempted {
+		restorablePlugin, ok := c.resources[entry.resourceName].(RestorablePlugin)
+		if !ok {
+			c.log.Error(ErrRestoreNotSupported, "failed to restore preempted claim", "resource", entry.resourceName)
+			continue
+		}
+
+		if err := restorablePlugin.RestoreClaim(entry.claim); err != nil {
+			c.log.Error(err, "failed to restore preempted claim", "resource", entry.resourceName)
+			continue
+		}
+		c.issued = append(c.issued, entry)
+	}
+}
+
+func (c *claimer) preemptionCandidates(resources v1alpha1.ResourceList, priority int32) []PreemptionCandidate {
+	reserved := map[uint64]bool{}
+	for _, reservation := range c.reservations {
+		for resourceName, resourceClaim := range reservation.Claims {
+			if i := c.issuedIndex(resourceName, resourceClaim); i >= 0 {
+				reserved[c.issued[i].group] = true
+			}
+		}
+	}
+
+	var (
+		candidates []PreemptionCandidate
+		relevant   = map[uint64]bool{}
+		byGroup    = map[uint64]int{}
+	)
+	for _, entry := range c.issued {
+		if entry.priority >= priority || reserved[entry.group] {
+			continue
+		}
+
+		idx, ok := byGroup[entry.group]
+		if !ok {
+			idx = len(candidates)
+			byGroup[entry.group] = idx
+			candidates = append(candidates, PreemptionCandidate{
+				Claims:   Claims{},
+				Identity: entry.identity,
+				Priority: entry.priority,
+				group:    entry.group,
+			})
+		}
+		candidates[idx].Claims[entry.resourceName] = entry.claim
+
+		if _, ok := resources[entry.resourceName]; ok {
+			relevant[entry.group] = true
+		}
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(candidate PreemptionCandidate) bool {
+		return !relevant[candidate.group]
+	})
+	slices.SortStableFunc(candidates, func(a, b PreemptionCandidate) int {
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+
+	return candidates
+}
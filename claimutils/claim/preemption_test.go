@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Preemption", func() {
+	var (
+		resourceClaimer claim.Claimer
+		offered         [][]claim.PreemptionCandidate
+	)
+
+	oneGPU := v1alpha1.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("1"),
+	}
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		offered = nil
+
+		var err error
+		resourceClaimer, err = claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				Preemptor: claim.PreemptorFunc(func(
+					_ v1alpha1.ResourceList,
+					_ int32,
+					candidates []claim.PreemptionCandidate,
+				) []claim.PreemptionCandidate {
+					offered = append(offered, candidates)
+					return candidates[:1]
+				}),
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		startClaimer(ctx, resourceClaimer)
+	})
+
+	It("should preempt lower-priority claims to make room", func(ctx SpecContext) {
+		By("claiming all devices with low priority")
+		lowClaims, err := resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("low"), claim.WithPriority(1))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("lowest"), claim.WithPriority(0))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("claiming with high priority")
+		highClaims, err := resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("high"), claim.WithPriority(10))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("asserting the lowest priority claim got preempted")
+		Expect(offered).To(HaveLen(1))
+		Expect(offered[0]).To(HaveLen(2))
+		Expect(offered[0][0].Identity).To(Equal("lowest"))
+		Expect(offered[0][1].Identity).To(Equal("low"))
+		Expect(offered[0][1].Claims).To(Equal(lowClaims))
+
+		By("asserting the remaining claims are still held")
+		Expect(resourceClaimer.Release(ctx, lowClaims)).To(Succeed())
+		Expect(resourceClaimer.Release(ctx, highClaims)).To(Succeed())
+	})
+
+	It("should fail if no lower-priority claim is available", func(ctx SpecContext) {
+		By("claiming all devices")
+		_, err := resourceClaimer.Claim(ctx, oneGPU, claim.WithPriority(5))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = resourceClaimer.Claim(ctx, oneGPU, claim.WithPriority(10))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("claiming with equal priority")
+		_, err = resourceClaimer.Claim(ctx, oneGPU, claim.WithPriority(5))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+		Expect(offered).To(BeEmpty())
+	})
+
+	It("should not preempt claims that don't make room", func(ctx SpecContext) {
+		By("claiming all devices with low priority")
+		_, err := resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("low"), claim.WithPriority(1))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("lowest"), claim.WithPriority(0))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("claiming more than the selected victim frees with high priority")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		}, claim.WithIdentity("high"), claim.WithPriority(10))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+		Expect(offered).To(HaveLen(1))
+
+		By("asserting all devices are still claimed")
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+	})
+
+	It("should restore preempted claims if the claim fails anyway", func(ctx SpecContext) {
+		By("init claimer with a plugin not reporting its capacity")
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				Preemptor: claim.PreemptorFunc(func(
+					_ v1alpha1.ResourceList,
+					_ int32,
+					candidates []claim.PreemptionCandidate,
+				) []claim.PreemptionCandidate {
+					return candidates[:1]
+				}),
+			},
+			restorableOnly{gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+				},
+			}, nil).(claim.RestorablePlugin)},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("claiming all devices with low priority")
+		lowClaims, err := resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("low"), claim.WithPriority(1))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("lowest"), claim.WithPriority(0))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("claiming more than the selected victim frees with high priority")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		}, claim.WithIdentity("high"), claim.WithPriority(10))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		By("asserting the preempted claim is claimed and issued again")
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		issued, err := resourceClaimer.ListIssued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued).To(HaveLen(2))
+		Expect(resourceClaimer.Release(ctx, lowClaims)).To(Succeed())
+	})
+})
+
+// restorableOnly hides all interfaces of a plugin except claim.RestorablePlugin.
+type restorableOnly struct {
+	claim.RestorablePlugin
+}
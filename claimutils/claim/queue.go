@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import "time"
+
+// Operation is a request type processed by the claimer loop.
+type Operation string
+
+const (
+	OperationClaim   Operation = "Claim"
+	OperationRelease Operation = "Release"
+)
+
+// MetricsCollector receives metrics of the claimer loop.
+type MetricsCollector interface {
+	// ObserveLatency records the time a request took from being queued until the loop processed it.
+	ObserveLatency(operation Operation, latency time.Duration)
+}
+
+// QueueDepth returns the number of claim and release requests waiting for the claimer loop.
+// The request currently being processed is not counted.
+func (c *claimer) QueueDepth() (claim int, release int) {
+	return int(c.claimQueueDepth.Load()), int(c.releaseQueueDepth.Load())
+}
+
+func (c *claimer) observeLatency(operation Operation, queued time.Time) {
+	if c.metricsCollector == nil {
+		return
+	}
+	c.metricsCollector.ObserveLatency(operation, c.clock.Since(queued))
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type recordingCollector struct {
+	mu        sync.Mutex
+	latencies map[claim.Operation][]time.Duration
+}
+
+func (c *recordingCollector) ObserveLatency(operation claim.Operation, latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.latencies == nil {
+		c.latencies = map[claim.Operation][]time.Duration{}
+	}
+	c.latencies[operation] = append(c.latencies[operation], latency)
+}
+
+func (c *recordingCollector) observed(operation claim.Operation) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.latencies[operation])
+}
+
+// wedgingPlugin blocks every claim until unblocked, signaling the first one via entered.
+type wedgingPlugin struct {
+	claim.Plugin
+	entered chan struct{}
+	unblock chan struct{}
+}
+
+func (p *wedgingPlugin) Claim(quantity resource.Quantity) (claim.ResourceClaim, error) {
+	select {
+	case p.entered <- struct{}{}:
+	default:
+	}
+	<-p.unblock
+	return p.Plugin.Claim(quantity)
+}
+
+var _ = Describe("Queue Depth", func() {
+	It("should report the requests queued behind a slow plugin", func(ctx SpecContext) {
+		entered, unblock := make(chan struct{}, 1), make(chan struct{})
+		collector := &recordingCollector{}
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{MetricsCollector: collector},
+			&wedgingPlugin{
+				Plugin: gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+					devices: []pci.Address{{Bus: 0x17}, {Bus: 0x18}, {Bus: 0x19}, {Bus: 0x1a}},
+				}, nil),
+				entered: entered,
+				unblock: unblock,
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		var wg sync.WaitGroup
+		run := func(fn func()) {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				fn()
+			}()
+		}
+		claimGPU := func() {
+			_, err := resourceClaimer.Claim(context.Background(), v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("1"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("wedging the loop in a claim")
+		run(claimGPU)
+		Eventually(entered).Should(Receive())
+
+		By("queueing claims and releases behind it")
+		for range 3 {
+			run(claimGPU)
+		}
+		for range 2 {
+			run(func() {
+				Expect(resourceClaimer.Release(context.Background(), claim.Claims{})).To(Succeed())
+			})
+		}
+		Eventually(func() []int {
+			claims, releases := resourceClaimer.QueueDepth()
+			return []int{claims, releases}
+		}).Should(Equal([]int{3, 2}))
+
+		By("draining the queue")
+		close(unblock)
+		wg.Wait()
+		claims, releases := resourceClaimer.QueueDepth()
+		Expect(claims).To(BeZero())
+		Expect(releases).To(BeZero())
+		Expect(collector.observed(claim.OperationClaim)).To(Equal(4))
+		Expect(collector.observed(claim.OperationRelease)).To(Equal(2))
+	})
+})
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+// QuotaProvider returns the maximum quantity of a resource an identity may hold.
+// If ok is false, no quota applies to the identity and resource.
+type QuotaProvider interface {
+	Quota(identity string, resourceName v1alpha1.ResourceName) (max resource.Quantity, ok bool)
+}
+
+// StaticQuotas is a QuotaProvider with a fixed resource list per identity.
+type StaticQuotas map[string]v1alpha1.ResourceList
+
+func (q StaticQuotas) Quota(identity string, resourceName v1alpha1.ResourceName) (resource.Quantity, bool) {
+	quantity, ok := q[identity][resourceName]
+	return quantity, ok
+}
+
+func (c *claimer) quotaUsed(identity string, resourceName v1alpha1.ResourceName) resource.Quantity {
+	used := resource.Quantity{}
+	for _, entry := range c.issued {
+		if entry.identity == identity && entry.resourceName == resourceName {
+			used.Add(entry.claimed)
+		}
+	}
+	return used
+}
+
+// quotaRequested returns the quantity of a resource a claim counts against the quota with. Negative
+// sentinels like gpu.AllAvailable claim all free devices, so they count the free quantity of the plugin
+// and ok is false if it doesn't report one.
+func (c *claimer) quotaRequested(resourceName v1alpha1.ResourceName, quantity resource.Quantity) (resource.Quantity, bool) {
+	if quantity.Sign() >= 0 {
+		return quantity, true
+	}
+
+	free, _, ok := pluginCapacity(c.resources[resourceName])
+	return free, ok
+}
+
+func (c *claimer) checkQuota(identity string, resources v1alpha1.ResourceList) error {
+	if c.quotaProvider == nil || identity == "" {
+		return nil
+	}
+
+	var quotaErrors []error
+	for resourceName, quantity := range resources {
+		maxQuantity, ok := c.quotaProvider.Quota(identity, resourceName)
+		if !ok {
+			continue
+		}
+
+		requested, ok := c.quotaRequested(resourceName, quantity)
+		if !ok {
+			quotaErrors = append(quotaErrors, fmt.Errorf(
+				"quota of %s for %s exceeded: requested all available of unknown quantity, max %s",
+				identity, resourceName, maxQuantity.String(),
+			))
+			continue
+		}
+
+		used := c.quotaUsed(identity, resourceName)
+		used.Add(requested)
+		if used.Cmp(maxQuantity) > 0 {
+			quotaErrors = append(quotaErrors, fmt.Errorf(
+				"quota of %s for %s exceeded: requested %s, max %s",
+				identity, resourceName, requested.String(), maxQuantity.String(),
+			))
+		}
+	}
+	if len(quotaErrors) > 0 {
+		return errors.Join(ErrQuotaExceeded, errors.Join(quotaErrors...))
+	}
+
+	return nil
+}
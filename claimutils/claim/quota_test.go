@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Quotas", func() {
+	It("should enforce per-identity quotas", func(ctx SpecContext) {
+		By("init claimer with quotas")
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				QuotaProvider: claim.StaticQuotas{
+					"tenant-a": v1alpha1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("1"),
+					},
+				},
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+					{Function: 2},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+
+		oneGPU := v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}
+
+		By("exhausting the quota of tenant-a")
+		tenantAClaims, err := resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("tenant-a"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("tenant-a"))
+		Expect(err).To(MatchError(claim.ErrQuotaExceeded))
+
+		By("claiming as tenant without quota while devices remain free")
+		_, err = resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("tenant-b"))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("releasing returns the quota")
+		Expect(resourceClaimer.Release(ctx, tenantAClaims)).To(Succeed())
+
+		_, err = resourceClaimer.Claim(ctx, oneGPU, claim.WithIdentity("tenant-a"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should count all available claims by the devices they take", func(ctx SpecContext) {
+		By("init claimer with quotas")
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				QuotaProvider: claim.StaticQuotas{
+					"tenant-a": v1alpha1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("2"),
+					},
+				},
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+					{Function: 2},
+					{Function: 3},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+
+		allAvailable := v1alpha1.ResourceList{
+			"nvidia.com/gpu": gpu.AllAvailable(),
+		}
+
+		By("rejecting all available while more devices are free than the quota allows")
+		_, err = resourceClaimer.Claim(ctx, allAvailable, claim.WithIdentity("tenant-a"))
+		Expect(err).To(MatchError(claim.ErrQuotaExceeded))
+
+		By("claiming all available once it fits into the quota")
+		tenantBClaims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		}, claim.WithIdentity("tenant-b"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = resourceClaimer.Claim(ctx, allAvailable, claim.WithIdentity("tenant-a"))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("rejecting a second claim exceeding the quota")
+		Expect(resourceClaimer.Release(ctx, tenantBClaims)).To(Succeed())
+
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}, claim.WithIdentity("tenant-a"))
+		Expect(err).To(MatchError(claim.ErrQuotaExceeded))
+	})
+})
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrOutstandingClaims = errors.New("resource has outstanding claims")
+)
+
+// RegisterPlugin initializes the plugin and adds it to the running claimer, e.g. for resources
+// discovered after startup. It fails with ErrNotStarted if the claimer is not running and with a
+// RegistrationError like NewResourceClaimerWithOptions if the plugin or its resource is already
+// registered. The plugin is initialized outside the claimer loop, bounded by
+// ClaimerOptions.InitTimeout, once it is checked not to be registered. If it is rejected after its
+// Init, e.g. by a concurrent registration or overlapping devices, it is shut down again.
+func (c *claimer) RegisterPlugin(ctx context.Context, plugin Plugin) error {
+	if err := c.ensureRunning(); err != nil {
+		return err
+	}
+
+	if err := c.checkRegistrable(plugin); err != nil {
+		return err
+	}
+
+	if err := c.initPlugin(plugin, c.initTimeout); err != nil {
+		return err
+	}
+
+	var registerErr error
+	if err := c.exec(ctx, func() {
+		registerErr = c.registerPlugin(plugin)
+	}); err != nil {
+		c.shutdownRejected(ctx, plugin)
+		return err
+	}
+
+	if registerErr != nil {
+		c.shutdownRejected(ctx, plugin)
+		return registerErr
+	}
+
+	c.log.V(1).Info("Registered plugin", "plugin", plugin.Name(), "resource", ResourceNameOf(plugin))
+	return nil
+}
+
+// checkRegistrable fails with a RegistrationError if the plugin or its resource is registered already.
+func (c *claimer) checkRegistrable(plugin Plugin) error {
+	c.pluginsMu.RLock()
+	defer c.pluginsMu.RUnlock()
+
+	if _, existing := c.plugins[plugin.Name()]; existing {
+		return &RegistrationError{Identifier: plugin.Name(), Err: ErrDuplicatePlugin}
+	}
+
+	resourceName := ResourceNameOf(plugin)
+	if _, existing := c.resources[resourceName]; existing {
+		return &RegistrationError{Identifier: string(resourceName), Err: ErrDuplicateResource}
+	}
+	return nil
+}
+
+// shutdownRejected shuts down an initialized plugin that was not registered, logging failures.
+func (c *claimer) shutdownRejected(ctx context.Context, plugin Plugin) {
+	ctx, cancel := c.withShutdownTimeout(ctx)
+	defer cancel()
+
+	if err := shutdownPlugin(ctx, plugin); err != nil {
+		c.log.Error(err, "Failed to shut down rejected plugin", "plugin", plugin.Name())
+	}
+}
+
+func (c *claimer) registerPlugin(plugin Plugin) error {
+	c.pluginsMu.Lock()
+	defer c.pluginsMu.Unlock()
+
+	if err := c.addPlugin(plugin); err != nil {
+		return err
+	}
+
+	if c.validateDevices {
+		if err := c.validateDeviceUniqueness(); err != nil {
+			delete(c.plugins, plugin.Name())
+			delete(c.resources, ResourceNameOf(plugin))
+			return err
+		}
+	}
+	return nil
+}
+
+// UnregisterPlugin removes the plugin with the given name from the running claimer and shuts it
+// down if it implements ShutdownablePlugin. It fails with ErrOutstandingClaims while claims or
+// reservations of its resource are not released and with ErrMissingPlugins if no such plugin is
+// registered. The resource is uncordoned. The Shutdown is bounded like on stopping the claimer.
+func (c *claimer) UnregisterPlugin(name string) error {
+	ctx, cancel := c.withOperationTimeout(context.Background())
+	defer cancel()
+
+	var (
+		plugin        Plugin
+		unregisterErr error
+	)
+	if err := c.exec(ctx, func() {
+		plugin, unregisterErr = c.unregisterPlugin(name)
+	}); err != nil {
+		return err
+	}
+
+	if unregisterErr != nil {
+		return unregisterErr
+	}
+
+	c.log.V(1).Info("Unregistered plugin", "plugin", name, "resource", ResourceNameOf(plugin))
+	shutdownCtx, shutdownCancel := c.withShutdownTimeout(ctx)
+	defer shutdownCancel()
+	if err := shutdownPlugin(shutdownCtx, plugin); err != nil {
+		c.log.Error(err, "Failed to shut down plugin", "plugin", name)
+	}
+	return nil
+}
+
+func (c *claimer) unregisterPlugin(name string) (Plugin, error) {
+	plugin, ok := c.plugins[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: plugin %s", ErrMissingPlugins, name)
+	}
+
+	resourceName := ResourceNameOf(plugin)
+	var outstanding int
+	for _, entry := range c.issued {
+		if entry.resourceName == resourceName {
+			outstanding++
+		}
+	}
+	if outstanding > 0 {
+		return nil, fmt.Errorf("%s: %d claims: %w", resourceName, outstanding, ErrOutstandingClaims)
+	}
+
+	c.pluginsMu.Lock()
+	delete(c.plugins, name)
+	delete(c.resources, resourceName)
+	c.pluginsMu.Unlock()
+
+	c.cordonMu.Lock()
+	delete(c.cordoned, resourceName)
+	c.cordonMu.Unlock()
+
+	return plugin, nil
+}
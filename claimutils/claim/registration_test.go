@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Plugin Registration", func() {
+	oneUnit := v1alpha1.ResourceList{
+		"example.com/late": resource.MustParse("1"),
+	}
+
+	It("should register plugins after the claimer started", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(log.FromContext(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		latePlugin := claimtest.NewFakePlugin("example.com/late", 1)
+
+		By("rejecting registrations before the claimer started")
+		Expect(resourceClaimer.RegisterPlugin(ctx, latePlugin)).To(MatchError(claim.ErrNotStarted))
+
+		startClaimer(ctx, resourceClaimer)
+
+		By("rejecting claims of the resource before registering it")
+		_, err = resourceClaimer.Claim(ctx, oneUnit)
+		Expect(err).To(MatchError(claim.ErrMissingPlugins))
+
+		By("registering the plugin")
+		Expect(resourceClaimer.RegisterPlugin(ctx, latePlugin)).To(Succeed())
+		Expect(latePlugin.Calls().Init).To(Equal(1))
+
+		By("rejecting a plugin of the same name without initializing it")
+		duplicatePlugin := claimtest.NewFakePlugin("example.com/late", 1)
+		err = resourceClaimer.RegisterPlugin(ctx, duplicatePlugin)
+		Expect(err).To(MatchError(claim.ErrDuplicatePlugin))
+		Expect(duplicatePlugin.Calls().Init).To(BeZero())
+
+		By("claiming the resource")
+		_, err = resourceClaimer.Claim(ctx, oneUnit)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(latePlugin.Claimed()).To(Equal(int64(1)))
+	})
+
+	It("should unregister plugins without outstanding claims", func(ctx SpecContext) {
+		latePlugin := &shutdownablePlugin{FakePlugin: claimtest.NewFakePlugin("example.com/late", 1)}
+		resourceClaimer, err := claim.NewResourceClaimer(log.FromContext(ctx), latePlugin)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		Expect(resourceClaimer.UnregisterPlugin("example.com/unknown")).To(MatchError(claim.ErrMissingPlugins))
+
+		By("rejecting the unregistration while a claim is outstanding")
+		claims, err := resourceClaimer.Claim(ctx, oneUnit)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceClaimer.UnregisterPlugin("example.com/late")).To(MatchError(claim.ErrOutstandingClaims))
+
+		By("unregistering the plugin once the claim is released")
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+		Expect(resourceClaimer.UnregisterPlugin("example.com/late")).To(Succeed())
+		Expect(latePlugin.shutdowns.Load()).To(Equal(int32(1)))
+		Expect(latePlugin.ctxBounded.Load()).To(BeTrue(), "expected a shutdown context with deadline")
+
+		_, err = resourceClaimer.Claim(ctx, oneUnit)
+		Expect(err).To(MatchError(claim.ErrMissingPlugins))
+
+		By("registering the resource again")
+		Expect(resourceClaimer.RegisterPlugin(ctx, claimtest.NewFakePlugin("example.com/late", 1))).To(Succeed())
+		_, err = resourceClaimer.Claim(ctx, oneUnit)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
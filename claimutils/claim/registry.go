@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+)
+
+var (
+	ErrMissingClaimCodec   = errors.New("no claim codec for resource")
+	ErrDuplicateClaimCodec = errors.New("claim codec already registered")
+)
+
+// ClaimCodec serializes the claims of a plugin to and from JSON.
+type ClaimCodec interface {
+	EncodeClaim(claim ResourceClaim) (json.RawMessage, error)
+	DecodeClaim(data json.RawMessage) (ResourceClaim, error)
+}
+
+// Registry holds the ClaimCodec per resource.
+type Registry struct {
+	mu     sync.RWMutex
+	codecs map[v1alpha1.ResourceName]ClaimCodec
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		codecs: map[v1alpha1.ResourceName]ClaimCodec{},
+	}
+}
+
+// Register registers the codec for the given resource.
+func (r *Registry) Register(resourceName v1alpha1.ResourceName, codec ClaimCodec) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, existing := r.codecs[resourceName]; existing {
+		return fmt.Errorf("%s: %w", resourceName, ErrDuplicateClaimCodec)
+	}
+	r.codecs[resourceName] = codec
+	return nil
+}
+
+func (r *Registry) codec(resourceName v1alpha1.ResourceName) (ClaimCodec, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	codec, ok := r.codecs[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrMissingClaimCodec, resourceName)
+	}
+	return codec, nil
+}
+
+// EncodeClaim encodes a single resource claim with the codec registered for the resource.
+func (r *Registry) EncodeClaim(resourceName v1alpha1.ResourceName, claim ResourceClaim) (json.RawMessage, error) {
+	codec, err := r.codec(resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := codec.EncodeClaim(claim)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode claim for resource %s: %w", resourceName, err)
+	}
+	return data, nil
+}
+
+// DecodeClaim decodes a single resource claim with the codec registered for the resource.
+func (r *Registry) DecodeClaim(resourceName v1alpha1.ResourceName, data json.RawMessage) (ResourceClaim, error) {
+	codec, err := r.codec(resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := codec.DecodeClaim(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode claim for resource %s: %w", resourceName, err)
+	}
+	return claim, nil
+}
+
+// Encode encodes all claims with the codecs registered for their resources.
+func (r *Registry) Encode(claims Claims) (map[v1alpha1.ResourceName]json.RawMessage, error) {
+	out := make(map[v1alpha1.ResourceName]json.RawMessage, len(claims))
+	for resourceName, claim := range claims {
+		data, err := r.EncodeClaim(resourceName, claim)
+		if err != nil {
+			return nil, err
+		}
+		out[resourceName] = data
+	}
+	return out, nil
+}
+
+// Decode decodes all claims with the codecs registered for their resources.
+func (r *Registry) Decode(data map[v1alpha1.ResourceName]json.RawMessage) (Claims, error) {
+	claims := make(Claims, len(data))
+	for resourceName, claimData := range data {
+		claim, err := r.DecodeClaim(resourceName, claimData)
+		if err != nil {
+			return nil, err
+		}
+		claims[resourceName] = claim
+	}
+	return claims, nil
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DefaultRemoteTimeout bounds the requests of a remote plugin whose client has no timeout.
+const DefaultRemoteTimeout = 10 * time.Second
+
+// RemoteClaim is a claim issued by a remote allocator.
+type RemoteClaim struct {
+	ID string `json:"id"`
+}
+
+type remoteClaimRequest struct {
+	Quantity resource.Quantity `json:"quantity"`
+}
+
+// NewRemotePlugin returns a plugin claiming from a cluster-wide pool managed by the REST service at baseURL.
+// Claims are created via POST {baseURL}/claims and released via DELETE {baseURL}/claims/{id}.
+// The service responds with 409 Conflict if the pool cannot satisfy a claim. The capacity of the pool is
+// not known to the plugin, so it is not reported by the claimer's Availability and Capacity.
+// If client is nil, a client with a timeout of DefaultRemoteTimeout is used. Requests are bounded by the
+// timeout of the client, or DefaultRemoteTimeout if it has none.
+func NewRemotePlugin(name, baseURL string, client *http.Client) Plugin {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultRemoteTimeout}
+	}
+
+	timeout := client.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRemoteTimeout
+	}
+
+	return &remotePlugin{
+		name:    name,
+		baseURL: baseURL,
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+type remotePlugin struct {
+	name    string
+	baseURL string
+	client  *http.Client
+	timeout time.Duration
+}
+
+// CanClaim does not contact the remote service, availability is only known once Claim is issued.
+func (r *remotePlugin) CanClaim(quantity resource.Quantity) bool {
+	return quantity.Sign() >= 0
+}
+
+func (r *remotePlugin) Claim(quantity resource.Quantity) (ResourceClaim, error) {
+	claimsURL, err := url.JoinPath(r.baseURL, "claims")
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+
+	body, err := json.Marshal(remoteClaimRequest{Quantity: quantity})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal claim request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, claimsURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create claim request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim %s: %w", r.name, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+	case http.StatusConflict:
+		return nil, ErrInsufficientResources
+	default:
+		return nil, unexpectedStatus(resp)
+	}
+
+	var claim RemoteClaim
+	if err := json.NewDecoder(resp.Body).Decode(&claim); err != nil {
+		return nil, fmt.Errorf("failed to decode claim response: %w", err)
+	}
+	if claim.ID == "" {
+		return nil, fmt.Errorf("claim response of %s has no id", r.name)
+	}
+
+	return claim, nil
+}
+
+func (r *remotePlugin) Release(claim ResourceClaim) error {
+	remoteClaim, ok := claim.(RemoteClaim)
+	if !ok || remoteClaim.ID == "" {
+		return ErrInvalidResourceClaim
+	}
+
+	claimURL, err := url.JoinPath(r.baseURL, "claims", remoteClaim.ID)
+	if err != nil {
+		return fmt.Errorf("invalid base url: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, claimURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create release request: %w", err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to release %s claim %s: %w", r.name, remoteClaim.ID, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return ErrInvalidResourceClaim
+	default:
+		return unexpectedStatus(resp)
+	}
+}
+
+func (r *remotePlugin) Init() error {
+	return nil
+}
+
+func (r *remotePlugin) Name() string {
+	return r.name
+}
+
+func unexpectedStatus(resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ = Describe("RemotePlugin", func() {
+	var (
+		server *httptest.Server
+		plugin claim.Plugin
+		free   int64
+	)
+
+	BeforeEach(func() {
+		free = 2
+		claimed := map[string]int64{}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /claims", func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Quantity resource.Quantity `json:"quantity"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if req.Quantity.Value() > free {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			free -= req.Quantity.Value()
+			id := "claim-" + req.Quantity.String()
+			claimed[id] = req.Quantity.Value()
+
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": id})
+		})
+		mux.HandleFunc("DELETE /claims/{id}", func(w http.ResponseWriter, r *http.Request) {
+			quantity, ok := claimed[r.PathValue("id")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			free += quantity
+			delete(claimed, r.PathValue("id"))
+			w.WriteHeader(http.StatusNoContent)
+		})
+		slow := make(chan struct{})
+		mux.HandleFunc("POST /slow/claims", func(w http.ResponseWriter, r *http.Request) {
+			<-slow
+		})
+
+		server = httptest.NewServer(mux)
+		DeferCleanup(server.Close)
+		DeferCleanup(func() {
+			close(slow)
+		})
+
+		plugin = claim.NewRemotePlugin("nvidia.com/gpu", server.URL, server.Client())
+		Expect(plugin.Init()).To(Succeed())
+	})
+
+	It("should claim and release from the remote pool", func() {
+		resourceClaim, err := plugin.Claim(resource.MustParse("2"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceClaim).To(Equal(claim.RemoteClaim{ID: "claim-2"}))
+		Expect(free).To(BeZero())
+
+		Expect(plugin.Release(resourceClaim)).To(Succeed())
+		Expect(free).To(Equal(int64(2)))
+
+		By("releasing the claim again")
+		Expect(plugin.Release(resourceClaim)).To(MatchError(claim.ErrInvalidResourceClaim))
+	})
+
+	It("should map conflicts to insufficient resources", func() {
+		_, err := plugin.Claim(resource.MustParse("3"))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+		Expect(free).To(Equal(int64(2)))
+	})
+
+	It("should claim with the default client", func() {
+		plugin := claim.NewRemotePlugin("nvidia.com/gpu", server.URL, nil)
+		Expect(plugin.Claim(resource.MustParse("1"))).To(Equal(claim.RemoteClaim{ID: "claim-1"}))
+	})
+
+	It("should bound requests by the client timeout", func() {
+		client := server.Client()
+		client.Timeout = 50 * time.Millisecond
+		plugin := claim.NewRemotePlugin("nvidia.com/gpu", server.URL+"/slow", client)
+
+		start := time.Now()
+		_, err := plugin.Claim(resource.MustParse("1"))
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+})
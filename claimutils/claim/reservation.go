@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+)
+
+var (
+	ErrReservationNotFound = errors.New("reservation not found")
+)
+
+// Reservation is a set of claims that is released automatically once it expires,
+// unless it is committed before.
+type Reservation struct {
+	ID        string
+	Claims    Claims
+	ExpiresAt time.Time
+}
+
+// Reserve claims the given resources for the given ttl. The claims of a reservation must not be
+// released via Release, they are either released by the reaper or handed over by Commit.
+func (c *claimer) Reserve(
+	ctx context.Context,
+	resources v1alpha1.ResourceList,
+	ttl time.Duration,
+	opts ...ClaimOption,
+) (Reservation, error) {
+	if err := c.checkSchema(resources); err != nil {
+		return Reservation{}, err
+	}
+
+	if err := c.checkPluginsForResources(resources); err != nil {
+		return Reservation{}, errors.Join(ErrMissingPlugins, err)
+	}
+
+	claimOpts := newClaimOptions(opts)
+	claimOpts.stack = c.callerStack()
+
+	var (
+		reservation Reservation
+		reserveErr  error
+	)
+	if err := c.exec(ctx, func() {
+		claims, err := c.claim(resources, claimOpts)
+		if err != nil {
+			reserveErr = err
+			return
+		}
+
+		c.nextReservation++
+		stored := &Reservation{
+			ID:        strconv.FormatUint(c.nextReservation, 10),
+			Claims:    claims,
+			ExpiresAt: c.clock.Now().Add(ttl),
+		}
+		c.reservations[stored.ID] = stored
+
+		reservation = *stored
+		reservation.Claims = stored.Claims.Clone()
+	}); err != nil {
+		return Reservation{}, err
+	}
+
+	return reservation, reserveErr
+}
+
+// Commit turns the reservation with the given id into regular claims that have to be released via Release.
+func (c *claimer) Commit(ctx context.Context, id string) (Claims, error) {
+	var (
+		claims    Claims
+		commitErr error
+	)
+	if err := c.exec(ctx, func() {
+		reservation, ok := c.reservations[id]
+		if !ok {
+			commitErr = fmt.Errorf("reservation %s: %w", id, ErrReservationNotFound)
+			return
+		}
+
+		delete(c.reservations, id)
+		claims = reservation.Claims
+	}); err != nil {
+		return nil, err
+	}
+
+	return claims, commitErr
+}
+
+func (c *claimer) reapReservations() {
+	now := c.clock.Now()
+	for id, reservation := range c.reservations {
+		if reservation.ExpiresAt.After(now) {
+			continue
+		}
+
+		c.log.V(1).Info("Releasing expired reservation", "id", id)
+		if err := c.release(reservation.Claims); err != nil {
+			c.log.Error(errors.Join(ErrReleaseClaim, err), "failed to release expired reservation", "id", id)
+		}
+		delete(c.reservations, id)
+	}
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	testingclock "k8s.io/utils/clock/testing"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Reservations", func() {
+	It("should release expired reservations", func(ctx SpecContext) {
+		fakeClock := testingclock.NewFakeClock(time.Now())
+
+		By("init plugin")
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				Clock:        fakeClock,
+				ReapInterval: time.Second,
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+
+		By("reserving all devices")
+		reservation, err := resourceClaimer.Reserve(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		}, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reservation.Claims).To(HaveKey(v1alpha1.ResourceName("nvidia.com/gpu")))
+
+		By("failing to claim while reserved")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		By("advancing the clock past the ttl")
+		fakeClock.Step(2 * time.Minute)
+
+		By("claiming the auto-released devices")
+		Eventually(func() error {
+			_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("2"),
+			})
+			return err
+		}).Should(Succeed())
+
+		By("failing to commit the expired reservation")
+		_, err = resourceClaimer.Commit(ctx, reservation.ID)
+		Expect(err).To(MatchError(claim.ErrReservationNotFound))
+	})
+
+	It("should keep committed reservations", func(ctx SpecContext) {
+		fakeClock := testingclock.NewFakeClock(time.Now())
+
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				Clock:        fakeClock,
+				ReapInterval: time.Second,
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+
+		By("reserving and committing")
+		reservation, err := resourceClaimer.Reserve(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		claims, err := resourceClaimer.Commit(ctx, reservation.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims).To(Equal(reservation.Claims))
+
+		By("advancing the clock past the ttl")
+		fakeClock.Step(2 * time.Minute)
+
+		By("asserting the device stays claimed")
+		Consistently(func() error {
+			_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("1"),
+			})
+			return err
+		}).WithTimeout(500 * time.Millisecond).Should(MatchError(claim.ErrInsufficientResources))
+	})
+
+	It("should not be affected by mutations of the returned claims", func(ctx SpecContext) {
+		fakeClock := testingclock.NewFakeClock(time.Now())
+
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				Clock:        fakeClock,
+				ReapInterval: time.Second,
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+
+		By("claiming and mutating the returned claims")
+		claims, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		delete(claims, "nvidia.com/gpu")
+
+		listed, err := resourceClaimer.ListClaims(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listed).To(ConsistOf(HaveKey(v1alpha1.ResourceName("nvidia.com/gpu"))))
+
+		By("releasing the claim via the listed claims")
+		for _, listedClaims := range listed {
+			Expect(resourceClaimer.Release(ctx, listedClaims)).To(Succeed())
+		}
+
+		By("reserving and mutating the returned claims")
+		reservation, err := resourceClaimer.Reserve(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		delete(reservation.Claims, "nvidia.com/gpu")
+
+		By("advancing the clock past the ttl")
+		fakeClock.Step(2 * time.Minute)
+
+		By("asserting the original claims got released")
+		Eventually(func() error {
+			_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("1"),
+			})
+			return err
+		}).Should(Succeed())
+	})
+})
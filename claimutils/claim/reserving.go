@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NewReservingPlugin returns a plugin that keeps reserve units of the inner plugin unclaimed, e.g.
+// as headroom for the host. Claims that would dip into the reserve fail with ErrInsufficientResources.
+// Negative sentinel quantities cannot be checked against the reserve and are refused. The reserve is
+// not reported as available.
+func NewReservingPlugin(inner Plugin, reserve int64) Plugin {
+	return &reservingPlugin{
+		inner:   inner,
+		reserve: *resource.NewQuantity(reserve, resource.DecimalSI),
+	}
+}
+
+type reservingPlugin struct {
+	inner   Plugin
+	reserve resource.Quantity
+}
+
+func (r *reservingPlugin) CanClaim(quantity resource.Quantity) bool {
+	if quantity.Sign() < 0 {
+		return false
+	}
+
+	withReserve := quantity.DeepCopy()
+	withReserve.Add(r.reserve)
+	if _, err := Int64Value(withReserve); err != nil {
+		return false
+	}
+	return r.inner.CanClaim(withReserve)
+}
+
+func (r *reservingPlugin) Claim(quantity resource.Quantity) (ResourceClaim, error) {
+	if !r.CanClaim(quantity) {
+		return nil, ErrInsufficientResources
+	}
+	return r.inner.Claim(quantity)
+}
+
+func (r *reservingPlugin) Release(claim ResourceClaim) error {
+	return r.inner.Release(claim)
+}
+
+func (r *reservingPlugin) capacity() (free, total resource.Quantity, ok bool) {
+	free, total, ok = pluginCapacity(r.inner)
+	free.Sub(r.reserve)
+	if free.Sign() < 0 {
+		free = *resource.NewQuantity(0, resource.DecimalSI)
+	}
+	return free, total, ok
+}
+
+func (r *reservingPlugin) wrapped() Plugin {
+	return r.inner
+}
+
+func (r *reservingPlugin) Init() error {
+	return r.inner.Init()
+}
+
+func (r *reservingPlugin) Shutdown(ctx context.Context) error {
+	return shutdownPlugin(ctx, r.inner)
+}
+
+func (r *reservingPlugin) Name() string {
+	return r.inner.Name()
+}
+
+func (r *reservingPlugin) ResourceName() v1alpha1.ResourceName {
+	return ResourceNameOf(r.inner)
+}
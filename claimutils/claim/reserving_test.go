@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Reserving Plugin", func() {
+	oneGPU := v1alpha1.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("1"),
+	}
+
+	It("should keep the reserve unclaimed", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			claim.NewReservingPlugin(gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{}, {Function: 1}, {Function: 2}},
+			}, nil), 1),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("failing to claim into the reserve at once")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("3"),
+		})
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		By("claiming up to the reserve boundary")
+		claims, err := resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("failing past the reserve boundary")
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		By("restoring headroom on release")
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+		_, err = resourceClaimer.Claim(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not pass claims dipping into the reserve to the inner plugin", func() {
+		inner := claimtest.NewFakePlugin("nvidia.com/gpu", 2)
+		plugin := claim.NewReservingPlugin(inner, 1)
+
+		_, err := plugin.Claim(resource.MustParse("2"))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+		Expect(inner.Calls().Claim).To(BeZero())
+
+		resourceClaim, err := plugin.Claim(resource.MustParse("1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inner.Claimed()).To(Equal(int64(1)))
+
+		Expect(plugin.Release(resourceClaim)).To(Succeed())
+		Expect(inner.Claimed()).To(BeZero())
+		Expect(inner.Calls().Release).To(Equal(1))
+	})
+})
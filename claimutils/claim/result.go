@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ClaimStrategy is the strategy a resource got claimed with.
+type ClaimStrategy string
+
+const (
+	// ClaimStrategyDefault leaves the selection to the plugin.
+	ClaimStrategyDefault ClaimStrategy = "Default"
+	// ClaimStrategyNUMAAffinity prefers devices of the NUMA node given via WithNUMANode.
+	ClaimStrategyNUMAAffinity ClaimStrategy = "NUMAAffinity"
+	// ClaimStrategySelectionPolicy selects devices by ClaimerOptions.SelectionPolicy.
+	ClaimStrategySelectionPolicy ClaimStrategy = "SelectionPolicy"
+	// ClaimStrategyAntiAffinity avoids the placement of claims with the key given via WithAntiAffinity.
+	ClaimStrategyAntiAffinity ClaimStrategy = "AntiAffinity"
+	// ClaimStrategySticky prefers the devices of the previous claim given via WithStickyClaims.
+	ClaimStrategySticky ClaimStrategy = "Sticky"
+)
+
+// NUMAPlugin is implemented by plugins that can prefer devices of a NUMA node.
+type NUMAPlugin interface {
+	Plugin
+	// ClaimOnNUMANode claims like Claim, preferring devices of the given node. satisfied is false
+	// if devices of other or unknown nodes had to be claimed.
+	ClaimOnNUMANode(quantity resource.Quantity, node int) (claim ResourceClaim, satisfied bool, err error)
+}
+
+// PluginWarnings is implemented by plugins reporting where a claim they issued is degraded, e.g.
+// because a reserved device had to be used. The warnings are added to ClaimResult.Warnings.
+type PluginWarnings interface {
+	Plugin
+	// ClaimWarnings returns the warnings of a claim issued by the plugin, if any.
+	ClaimWarnings(claim ResourceClaim) []string
+}
+
+// ClaimResult holds the diagnostics of a claim.
+type ClaimResult struct {
+	// ID identifies the issued claims, see ListClaims. It is empty if the claim failed.
+	ID ClaimID
+	// Duration is the time from requesting the claim until it returned.
+	Duration time.Duration
+	// Strategies are the strategies the resources got claimed with.
+	Strategies map[v1alpha1.ResourceName]ClaimStrategy
+	// Warnings describe where the claim deviates from what was requested, e.g. a cross-NUMA fallback.
+	Warnings []string
+}
+
+func (r *ClaimResult) warnf(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// ClaimWithResult claims like Claim and additionally returns the diagnostics of the claim.
+func (c *claimer) ClaimWithResult(
+	ctx context.Context,
+	resources v1alpha1.ResourceList,
+	opts ...ClaimOption,
+) (Claims, ClaimResult, error) {
+	start := c.clock.Now()
+	claims, result, err := c.claimWithResult(ctx, c.callerStack(), resources, opts...)
+	result.Duration = c.clock.Since(start)
+	return claims, result, err
+}
+
+// claimResource claims a single resource from its plugin, honoring the anti-affinity, stickiness and
+// NUMA affinity of opts and the selection policy of the claimer, in that order.
+func (c *claimer) claimResource(
+	plugin Plugin,
+	resourceName v1alpha1.ResourceName,
+	quantity resource.Quantity,
+	opts ClaimOptions,
+) (ResourceClaim, error) {
+	if resourceClaim, ok, err := c.claimWithAntiAffinity(plugin, resourceName, quantity, opts); ok {
+		return resourceClaim, err
+	}
+	if resourceClaim, ok, err := c.claimSticky(plugin, resourceName, quantity, opts); ok {
+		return resourceClaim, err
+	}
+
+	numaPlugin, ok := plugin.(NUMAPlugin)
+	if opts.NUMANode == nil || !ok {
+		if policyPlugin, ok := plugin.(PolicyAware); ok && c.selectionPolicy != SelectionPolicyDefault {
+			opts.result.setStrategy(resourceName, ClaimStrategySelectionPolicy)
+			return policyPlugin.ClaimWithPolicy(quantity, c.selectionPolicy)
+		}
+
+		opts.result.setStrategy(resourceName, ClaimStrategyDefault)
+		return plugin.Claim(quantity)
+	}
+
+	opts.result.setStrategy(resourceName, ClaimStrategyNUMAAffinity)
+	resourceClaim, satisfied, err := numaPlugin.ClaimOnNUMANode(quantity, *opts.NUMANode)
+	if err != nil {
+		return nil, err
+	}
+	if !satisfied {
+		opts.result.warnf("%s: NUMA affinity to node %d not satisfied, fell back to cross-NUMA devices", resourceName, *opts.NUMANode)
+	}
+	return resourceClaim, nil
+}
+
+func (r *ClaimResult) setStrategy(resourceName v1alpha1.ResourceName, strategy ClaimStrategy) {
+	if r.Strategies == nil {
+		r.Strategies = map[v1alpha1.ResourceName]ClaimStrategy{}
+	}
+	r.Strategies[resourceName] = strategy
+}
+
+// pluginWarnings adds the warnings a PluginWarnings plugin reports for the claim to the result.
+func (r *ClaimResult) pluginWarnings(plugin Plugin, resourceName v1alpha1.ResourceName, claim ResourceClaim) {
+	warningPlugin, ok := plugin.(PluginWarnings)
+	if !ok {
+		return
+	}
+
+	for _, warning := range warningPlugin.ClaimWarnings(claim) {
+		r.warnf("%s: %s", resourceName, warning)
+	}
+}
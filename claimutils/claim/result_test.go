@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type mockInfoReader struct {
+	mockReader
+	infos []pci.DeviceInfo
+}
+
+func (m *mockInfoReader) ReadInfo() ([]pci.DeviceInfo, error) {
+	return m.infos, m.err
+}
+
+type warningPlugin struct {
+	*claimtest.FakePlugin
+}
+
+func (p warningPlugin) ClaimWarnings(resourceClaim claim.ResourceClaim) []string {
+	if resourceClaim.(*claimtest.FakeClaim).Quantity.Value() > 1 {
+		return []string{"claimed from the reserve"}
+	}
+	return nil
+}
+
+var _ = Describe("Claim Results", func() {
+	It("should report the strategy and cross-NUMA fallbacks", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockInfoReader{
+				infos: []pci.DeviceInfo{
+					{Address: pci.Address{Bus: 0x05}},
+					{Address: pci.Address{Bus: 0x17}, NUMANode: ptr.To(0)},
+					{Address: pci.Address{Bus: 0x97}, NUMANode: ptr.To(1)},
+					{Address: pci.Address{Bus: 0x98}, NUMANode: ptr.To(1)},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		By("claiming without NUMA affinity")
+		claims, result, err := resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Strategies).To(HaveKeyWithValue(v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategyDefault))
+		Expect(result.Warnings).To(BeEmpty())
+		Expect(result.Duration).To(BeNumerically(">", 0))
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+
+		By("claiming with satisfiable NUMA affinity")
+		claims, result, err = resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		}, claim.WithNUMANode(1))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Strategies).To(HaveKeyWithValue(v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategyNUMAAffinity))
+		Expect(result.Warnings).To(BeEmpty())
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(ConsistOf(
+			pci.Address{Bus: 0x97},
+			pci.Address{Bus: 0x98},
+		))
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+
+		By("not considering devices of unknown NUMA node on node 0")
+		claims, result, err = resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		}, claim.WithNUMANode(0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Warnings).To(BeEmpty())
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x17}}))
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+
+		By("claiming with NUMA affinity exceeding the node")
+		claims, result, err = resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		}, claim.WithNUMANode(0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Warnings).To(ConsistOf(ContainSubstring("cross-NUMA")))
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(ContainElement(pci.Address{Bus: 0x17}))
+	})
+
+	It("should report the warnings of plugins", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			warningPlugin{claimtest.NewFakePlugin("example.com/fake", 3)},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		_, result, err := resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"example.com/fake": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Warnings).To(BeEmpty())
+
+		_, result, err = resourceClaimer.ClaimWithResult(ctx, v1alpha1.ResourceList{
+			"example.com/fake": resource.MustParse("2"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Warnings).To(ConsistOf("example.com/fake: claimed from the reserve"))
+	})
+})
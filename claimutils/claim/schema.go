@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	ErrResourceSchemaViolation = errors.New("resource schema violation")
+)
+
+// ResourceSchema declares the resources a node serves and the quantities a single claim may request.
+type ResourceSchema map[v1alpha1.ResourceName]ResourceSpec
+
+// ResourceSpec bounds the quantity of a resource requested by a single claim. Nil bounds are not
+// checked. Bounds apply to the quantity as requested, so sentinels like gpu.AllAvailable fall below
+// a Min.
+type ResourceSpec struct {
+	Min *resource.Quantity
+	Max *resource.Quantity
+}
+
+// checkSchema fails with ErrResourceSchemaViolation if a resource is not declared by the schema or
+// its quantity is out of bounds. A nil schema accepts all resources.
+func (c *claimer) checkSchema(resources v1alpha1.ResourceList) error {
+	if c.schema == nil {
+		return nil
+	}
+
+	var violations []error
+	for _, resourceName := range slices.Sorted(maps.Keys(resources)) {
+		quantity := resources[resourceName]
+		spec, ok := c.schema[resourceName]
+		switch {
+		case !ok:
+			violations = append(violations, fmt.Errorf("resource %s is not served by this node", resourceName))
+		case spec.Min != nil && quantity.Cmp(*spec.Min) < 0:
+			violations = append(violations, fmt.Errorf(
+				"requested %s of %s, min %s", quantity.String(), resourceName, spec.Min.String(),
+			))
+		case spec.Max != nil && quantity.Cmp(*spec.Max) > 0:
+			violations = append(violations, fmt.Errorf(
+				"requested %s of %s, max %s", quantity.String(), resourceName, spec.Max.String(),
+			))
+		}
+	}
+	if len(violations) > 0 {
+		return errors.Join(ErrResourceSchemaViolation, errors.Join(violations...))
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Resource Schema", func() {
+	var fakePlugin *claimtest.FakePlugin
+
+	newClaimer := func(ctx SpecContext) interface {
+		claim.Claimer
+		Reserve(ctx context.Context, resources v1alpha1.ResourceList, ttl time.Duration, opts ...claim.ClaimOption) (claim.Reservation, error)
+	} {
+		fakePlugin = claimtest.NewFakePlugin("example.com/fake", 16)
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				ResourceSchema: claim.ResourceSchema{
+					"example.com/fake": {
+						Min: ptr.To(resource.MustParse("1")),
+						Max: ptr.To(resource.MustParse("8")),
+					},
+				},
+			},
+			fakePlugin,
+			claimtest.NewFakePlugin("example.com/undeclared", 4),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+		return resourceClaimer
+	}
+
+	It("should accept claims within the bounds", func(ctx SpecContext) {
+		resourceClaimer := newClaimer(ctx)
+
+		_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{"example.com/fake": resource.MustParse("8")})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject claims over the max", func(ctx SpecContext) {
+		resourceClaimer := newClaimer(ctx)
+
+		_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{"example.com/fake": resource.MustParse("9")})
+		Expect(err).To(MatchError(claim.ErrResourceSchemaViolation))
+		Expect(err).To(MatchError(ContainSubstring("requested 9 of example.com/fake, max 8")))
+
+		_, err = resourceClaimer.Reserve(ctx, v1alpha1.ResourceList{"example.com/fake": resource.MustParse("9")}, time.Hour)
+		Expect(err).To(MatchError(claim.ErrResourceSchemaViolation))
+
+		claimable, err := resourceClaimer.CanClaimAll(ctx, v1alpha1.ResourceList{"example.com/fake": resource.MustParse("9")})
+		Expect(err).To(MatchError(claim.ErrResourceSchemaViolation))
+		Expect(claimable).To(BeFalse())
+		Expect(fakePlugin.Calls().CanClaim).To(BeZero())
+	})
+
+	It("should reject claims under the min", func(ctx SpecContext) {
+		resourceClaimer := newClaimer(ctx)
+
+		_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{"example.com/fake": resource.MustParse("0")})
+		Expect(err).To(MatchError(claim.ErrResourceSchemaViolation))
+	})
+
+	It("should reject claims of undeclared resources before checking plugins", func(ctx SpecContext) {
+		resourceClaimer := newClaimer(ctx)
+
+		By("rejecting a resource served by a plugin but not declared")
+		_, err := resourceClaimer.Claim(ctx, v1alpha1.ResourceList{"example.com/undeclared": resource.MustParse("1")})
+		Expect(err).To(MatchError(claim.ErrResourceSchemaViolation))
+		Expect(err).To(MatchError(ContainSubstring("resource example.com/undeclared is not served by this node")))
+
+		_, err = resourceClaimer.CanClaimAll(ctx, v1alpha1.ResourceList{"example.com/undeclared": resource.MustParse("1")})
+		Expect(err).To(MatchError(claim.ErrResourceSchemaViolation))
+
+		By("rejecting an unknown resource")
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{"example.com/unknown": resource.MustParse("1")})
+		Expect(err).To(MatchError(claim.ErrResourceSchemaViolation))
+		Expect(err).NotTo(MatchError(claim.ErrMissingPlugins))
+	})
+})
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"time"
+)
+
+// defaultShutdownTimeout bounds the shutdown of the plugins if ClaimerOptions.OperationTimeout is unset.
+const defaultShutdownTimeout = 30 * time.Second
+
+// ShutdownablePlugin is implemented by plugins holding resources to clean up when the claimer stops,
+// e.g. open file descriptors or remote sessions.
+type ShutdownablePlugin interface {
+	Plugin
+	// Shutdown is called once when the claimer stops, via Stop or because the context of its Start
+	// is done, before its loop exits.
+	// The passed context is not cancelled with it, but bounded by ClaimerOptions.OperationTimeout, or
+	// 30 seconds if it is unset.
+	Shutdown(ctx context.Context) error
+}
+
+// shutdownPlugins shuts down all plugins implementing ShutdownablePlugin, logging failures.
+func (c *claimer) shutdownPlugins(ctx context.Context) {
+	ctx, cancel := c.withShutdownTimeout(ctx)
+	defer cancel()
+
+	for _, plugin := range c.plugins {
+		if err := shutdownPlugin(ctx, plugin); err != nil {
+			c.log.Error(err, "Failed to shut down plugin", "plugin", plugin.Name())
+		}
+	}
+}
+
+// withShutdownTimeout returns a context not cancelled with ctx, bounded by the operation timeout or
+// defaultShutdownTimeout if it is unset.
+func (c *claimer) withShutdownTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.operationTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
+
+// shutdownPlugin shuts down the plugin if it implements ShutdownablePlugin.
+func shutdownPlugin(ctx context.Context, plugin Plugin) error {
+	shutdownable, ok := plugin.(ShutdownablePlugin)
+	if !ok {
+		return nil
+	}
+	return shutdownable.Shutdown(ctx)
+}
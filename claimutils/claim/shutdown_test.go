@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type shutdownablePlugin struct {
+	*claimtest.FakePlugin
+	shutdowns  atomic.Int32
+	ctxLive    atomic.Bool
+	ctxBounded atomic.Bool
+}
+
+func (p *shutdownablePlugin) Shutdown(ctx context.Context) error {
+	p.ctxLive.Store(ctx.Err() == nil)
+	_, bounded := ctx.Deadline()
+	p.ctxBounded.Store(bounded)
+	p.shutdowns.Add(1)
+	return nil
+}
+
+var _ = Describe("Plugin Shutdown", func() {
+	It("should shut down plugins once when the claimer stops", func(ctx SpecContext) {
+		plugin := &shutdownablePlugin{FakePlugin: claimtest.NewFakePlugin("example.com/fake", 1)}
+		reservedPlugin := &shutdownablePlugin{FakePlugin: claimtest.NewFakePlugin("example.com/reserved", 2)}
+
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			plugin,
+			claim.NewReservingPlugin(reservedPlugin, 1),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+		Expect(plugin.shutdowns.Load()).To(BeZero())
+
+		cancel()
+		Eventually(plugin.shutdowns.Load).Should(Equal(int32(1)))
+		Eventually(reservedPlugin.shutdowns.Load).Should(Equal(int32(1)))
+		Consistently(plugin.shutdowns.Load).Should(Equal(int32(1)))
+		Expect(plugin.ctxLive.Load()).To(BeTrue(), "expected an uncancelled shutdown context")
+		Expect(plugin.ctxBounded.Load()).To(BeTrue(), "expected a shutdown context with deadline")
+	})
+})
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	ErrRestoreNotSupported = errors.New("plugin does not support restoring claims")
+)
+
+// RestorablePlugin is implemented by plugins that can mark a previously issued claim as claimed again.
+type RestorablePlugin interface {
+	Plugin
+	RestoreClaim(claim ResourceClaim) error
+}
+
+type snapshot struct {
+	Claims []snapshotClaim `json:"claims"`
+}
+
+type snapshotClaim struct {
+	ID              ClaimID               `json:"id,omitempty"`
+	Resource        v1alpha1.ResourceName `json:"resource"`
+	Identity        string                `json:"identity,omitempty"`
+	Quantity        resource.Quantity     `json:"quantity"`
+	Priority        int32                 `json:"priority,omitempty"`
+	RequestID       string                `json:"requestID,omitempty"`
+	AntiAffinityKey string                `json:"antiAffinityKey,omitempty"`
+	Claim           json.RawMessage       `json:"claim"`
+}
+
+// Snapshot serializes all outstanding claims and their ClaimIDs using the codecs of the claimer's
+// registry, along with the identity, priority, request id and anti-affinity key they were claimed
+// with. Outstanding reservations are captured as regular claims.
+func (c *claimer) Snapshot(ctx context.Context) ([]byte, error) {
+	var (
+		data        []byte
+		snapshotErr error
+	)
+	if err := c.exec(ctx, func() {
+		snap := snapshot{Claims: make([]snapshotClaim, 0, len(c.issued))}
+		for _, entry := range c.issued {
+			claimData, err := c.registry.EncodeClaim(entry.resourceName, entry.claim)
+			if err != nil {
+				snapshotErr = err
+				return
+			}
+
+			snap.Claims = append(snap.Claims, snapshotClaim{
+				ID:              claimIDOf(entry.group),
+				Resource:        entry.resourceName,
+				Identity:        entry.identity,
+				Quantity:        entry.quantity,
+				Priority:        entry.priority,
+				RequestID:       entry.requestID,
+				AntiAffinityKey: entry.antiAffinityKey,
+				Claim:           claimData,
+			})
+		}
+
+		data, snapshotErr = json.Marshal(snap)
+	}); err != nil {
+		return nil, err
+	}
+
+	return data, snapshotErr
+}
+
+// Restore marks all claims of a snapshot as claimed again. It has to be called on a started claimer
+// whose plugins are initialized. If any claim cannot be restored, no claim is restored. Claims keep
+// the ClaimID they were snapshotted with unless it is in use by the claimer already.
+func (c *claimer) Restore(ctx context.Context, data []byte) error {
+	snap := snapshot{}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to unmarshal snapshot: %w", err)
+	}
+
+	var restoreErr error
+	if err := c.exec(ctx, func() {
+		restoreErr = c.restore(snap)
+	}); err != nil {
+		return err
+	}
+
+	return restoreErr
+}
+
+func (c *claimer) restore(snap snapshot) error {
+	type restorable struct {
+		id     ClaimID
+		entry  issuedClaim
+		plugin RestorablePlugin
+	}
+
+	toRestore := make([]restorable, 0, len(snap.Claims))
+	for _, snapClaim := range snap.Claims {
+		plugin, ok := c.resources[snapClaim.Resource]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrMissingPlugins, snapClaim.Resource)
+		}
+
+		restorablePlugin, ok := plugin.(RestorablePlugin)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrRestoreNotSupported, snapClaim.Resource)
+		}
+
+		resourceClaim, err := c.registry.DecodeClaim(snapClaim.Resource, snapClaim.Claim)
+		if err != nil {
+			return err
+		}
+
+		toRestore = append(toRestore, restorable{
+			id: snapClaim.ID,
+			entry: issuedClaim{
+				resourceName: snapClaim.Resource,
+				claim:        resourceClaim,
+				identity:     snapClaim.Identity,
+				quantity:     snapClaim.Quantity,
+				claimed:      claimedQuantity(snapClaim.Quantity, resourceClaim),
+				priority:     snapClaim.Priority,
+				requestID:    snapClaim.RequestID,
+
+				antiAffinityKey: snapClaim.AntiAffinityKey,
+			},
+			plugin: restorablePlugin,
+		})
+	}
+
+	for i, r := range toRestore {
+		if err := r.plugin.RestoreClaim(r.entry.claim); err != nil {
+			for _, restored := range toRestore[:i] {
+				if err := restored.plugin.Release(restored.entry.claim); err != nil {
+					c.log.Error(errors.Join(ErrReleaseClaim, err), "failed to roll back restored claim")
+				}
+			}
+			return fmt.Errorf("failed to restore claim for resource %s: %w", r.entry.resourceName, err)
+		}
+	}
+
+	used := map[uint64]bool{}
+	for _, entry := range c.issued {
+		used[entry.group] = true
+	}
+	groups := map[ClaimID]uint64{}
+	for _, r := range toRestore {
+		group, ok := groups[r.id]
+		if !ok || r.id == "" {
+			group = c.restoredGroup(r.id, used)
+			used[group] = true
+			groups[r.id] = group
+		}
+
+		r.entry.group = group
+		c.issued = append(c.issued, r.entry)
+	}
+
+	return nil
+}
+
+// restoredGroup returns the group of the given ClaimID if it is not used yet, else a new group. Claims of
+// snapshots taken before ClaimIDs were recorded get a new group each.
+func (c *claimer) restoredGroup(id ClaimID, used map[uint64]bool) uint64 {
+	if group, err := strconv.ParseUint(string(id), 10, 64); err == nil && group > 0 && !used[group] {
+		c.nextIssuedGroup = max(c.nextIssuedGroup, group)
+		return group
+	}
+
+	c.nextIssuedGroup++
+	for used[c.nextIssuedGroup] {
+		c.nextIssuedGroup++
+	}
+	return c.nextIssuedGroup
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Snapshots", func() {
+	var (
+		registry *claim.Registry
+		devices  = []pci.Address{
+			{Bus: 0x17},
+			{Bus: 0x97},
+			{Bus: 0xca},
+		}
+	)
+
+	BeforeEach(func() {
+		registry = claim.NewRegistry()
+		Expect(registry.Register("nvidia.com/gpu", gpu.ClaimCodec{})).To(Succeed())
+	})
+
+	It("should restore a snapshot into a rebuilt claimer", func(ctx SpecContext) {
+		By("claiming devices")
+		original, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{Registry: registry},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{devices: devices}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, original)
+
+		claims, err := original.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		claimed := claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()
+
+		By("taking a snapshot")
+		data, err := original.Snapshot(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("rebuilding the claimer and restoring the snapshot")
+		rebuilt, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{Registry: registry},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{devices: devices}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, rebuilt)
+		Expect(rebuilt.Restore(ctx, data)).To(Succeed())
+
+		By("asserting only the unclaimed device is left")
+		_, err = rebuilt.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		})
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		remaining, err := rebuilt.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).NotTo(ContainElements(remaining["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()))
+
+		By("restoring the snapshot again fails without claiming anything")
+		Expect(rebuilt.Restore(ctx, data)).To(MatchError(gpu.ErrDeviceAlreadyClaimed))
+
+		By("releasing the original claims on the rebuilt claimer")
+		Expect(rebuilt.Release(ctx, claims)).To(Succeed())
+
+		_, err = rebuilt.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("2"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should restore the claims with their ids", func(ctx SpecContext) {
+		newClaimer := func() interface {
+			claim.Claimer
+			ClaimWithResult(ctx context.Context, resources v1alpha1.ResourceList, opts ...claim.ClaimOption) (claim.Claims, claim.ClaimResult, error)
+			ListClaims(ctx context.Context) (map[claim.ClaimID]claim.Claims, error)
+			Snapshot(ctx context.Context) ([]byte, error)
+			Restore(ctx context.Context, data []byte) error
+		} {
+			resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+				log.FromContext(ctx),
+				claim.ClaimerOptions{Registry: registry},
+				gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{devices: devices}, nil),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			startClaimer(ctx, resourceClaimer)
+			return resourceClaimer
+		}
+
+		By("claiming devices in two calls")
+		original := newClaimer()
+		oneGPU := v1alpha1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}
+		_, first, err := original.ClaimWithResult(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		_, second, err := original.ClaimWithResult(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := original.Snapshot(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("restoring the snapshot into a rebuilt claimer")
+		rebuilt := newClaimer()
+		Expect(rebuilt.Restore(ctx, data)).To(Succeed())
+
+		claims, err := rebuilt.ListClaims(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims).To(HaveLen(2))
+		Expect(claims).To(HaveKey(first.ID))
+		Expect(claims).To(HaveKey(second.ID))
+
+		By("issuing new claims with other ids")
+		_, third, err := rebuilt.ClaimWithResult(ctx, oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(third.ID).NotTo(BeElementOf(first.ID, second.ID))
+	})
+
+	It("should restore the options the claims were issued with", func(ctx SpecContext) {
+		newClaimer := func() interface {
+			claim.Claimer
+			ClaimWithResult(ctx context.Context, resources v1alpha1.ResourceList, opts ...claim.ClaimOption) (claim.Claims, claim.ClaimResult, error)
+			ClaimIdempotent(ctx context.Context, requestID string, resources v1alpha1.ResourceList, opts ...claim.ClaimOption) (claim.Claims, error)
+			ListIssued(ctx context.Context) ([]claim.IssuedClaim, error)
+			Snapshot(ctx context.Context) ([]byte, error)
+			Restore(ctx context.Context, data []byte) error
+		} {
+			resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+				log.FromContext(ctx),
+				claim.ClaimerOptions{Registry: registry},
+				gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockInfoReader{
+					infos: []pci.DeviceInfo{
+						{Address: pci.Address{Bus: 0x17}, NUMANode: ptr.To(0)},
+						{Address: pci.Address{Bus: 0x18}, NUMANode: ptr.To(0)},
+						{Address: pci.Address{Bus: 0x97}, NUMANode: ptr.To(1)},
+					},
+				}, nil),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			startClaimer(ctx, resourceClaimer)
+			return resourceClaimer
+		}
+
+		By("claiming with a priority, request id and anti-affinity key")
+		original := newClaimer()
+		oneGPU := v1alpha1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}
+		claims, err := original.ClaimIdempotent(ctx, "request-1", oneGPU,
+			claim.WithPriority(5), claim.WithAntiAffinity("ha"), claim.WithNUMANode(0),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x17}}))
+
+		data, err := original.Snapshot(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("restoring the snapshot into a rebuilt claimer")
+		rebuilt := newClaimer()
+		Expect(rebuilt.Restore(ctx, data)).To(Succeed())
+
+		issued, err := rebuilt.ListIssued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued).To(ConsistOf(HaveField("Priority", int32(5))))
+
+		By("returning the restored claims on a retry of the request")
+		retried, err := rebuilt.ClaimIdempotent(ctx, "request-1", oneGPU)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retried).To(Equal(claims))
+
+		issued, err = rebuilt.ListIssued(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issued).To(HaveLen(1))
+
+		By("avoiding the placement of the restored claims")
+		avoiding, result, err := rebuilt.ClaimWithResult(ctx, oneGPU, claim.WithAntiAffinity("ha"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Strategies).To(HaveKeyWithValue(v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategyAntiAffinity))
+		Expect(avoiding["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x97}}))
+	})
+})
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+)
+
+var (
+	ErrStatusNotSupported = errors.New("claim codec does not support status decoding")
+)
+
+// StatusClaim is implemented by claims that can be represented as a list of device strings,
+// e.g. to be stored in the status of an API object.
+type StatusClaim interface {
+	ResourceClaim
+	StatusDevices() []string
+}
+
+// StatusDecoder is implemented by claim codecs that can decode the device strings of a StatusClaim.
+type StatusDecoder interface {
+	ClaimCodec
+	DecodeStatus(devices []string) (ResourceClaim, error)
+}
+
+// ToStatus returns the devices per resource of all claims. Claims not implementing StatusClaim once
+// unwrapped by UnwrapClaim, e.g. hugepages, are listed with no devices, so the status records all
+// claimed resources.
+func (c Claims) ToStatus() map[string][]string {
+	status := make(map[string][]string, len(c))
+	for resourceName, resourceClaim := range c {
+		statusClaim, ok := UnwrapClaim(resourceClaim).(StatusClaim)
+		if !ok {
+			status[string(resourceName)] = []string{}
+			continue
+		}
+		status[string(resourceName)] = statusClaim.StatusDevices()
+	}
+	return status
+}
+
+// ClaimsFromStatus decodes claims returned by Claims.ToStatus with the codecs registered for their resources.
+func (r *Registry) ClaimsFromStatus(status map[string][]string) (Claims, error) {
+	claims := make(Claims, len(status))
+	for resource, devices := range status {
+		resourceName := v1alpha1.ResourceName(resource)
+		codec, err := r.codec(resourceName)
+		if err != nil {
+			return nil, err
+		}
+
+		decoder, ok := codec.(StatusDecoder)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrStatusNotSupported, resourceName)
+		}
+
+		resourceClaim, err := decoder.DecodeStatus(devices)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode status for resource %s: %w", resourceName, err)
+		}
+		claims[resourceName] = resourceClaim
+	}
+	return claims, nil
+}
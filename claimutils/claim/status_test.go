@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Claim Status", func() {
+	It("should round-trip claims through their status", func() {
+		registry := claim.NewRegistry()
+		Expect(registry.Register("nvidia.com/gpu", gpu.ClaimCodec{})).To(Succeed())
+
+		claims := claim.Claims{
+			"nvidia.com/gpu": gpu.NewGPUClaim([]pci.Address{{Bus: 0x17}, {Bus: 0x97}}),
+		}
+
+		status := claims.ToStatus()
+		Expect(status).To(Equal(map[string][]string{
+			"nvidia.com/gpu": {"0000:17:00.0", "0000:97:00.0"},
+		}))
+
+		decoded, err := registry.ClaimsFromStatus(status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(Equal(claims))
+
+		By("listing claims without devices")
+		Expect(claim.Claims{
+			"hugepages-2Mi":  claimtest.FakeClaim{},
+			"nvidia.com/gpu": claim.FallbackClaim{Claim: gpu.NewGPUClaim([]pci.Address{{Bus: 0x17}})},
+		}.ToStatus()).To(Equal(map[string][]string{
+			"hugepages-2Mi":  {},
+			"nvidia.com/gpu": {"0000:17:00.0"},
+		}))
+
+		By("failing for resources without codec")
+		_, err = registry.ClaimsFromStatus(map[string][]string{"amd.com/gpu": {"0000:17:00.0"}})
+		Expect(err).To(MatchError(claim.ErrMissingClaimCodec))
+	})
+})
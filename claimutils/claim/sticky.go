@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// StickyPlugin is implemented by plugins that can prefer the devices of a previously held claim.
+type StickyPlugin interface {
+	Plugin
+	// ClaimPreferring claims like Claim, preferring the devices of the previous claim if they are free.
+	// satisfied is false if devices of the previous claim were taken and others had to be claimed.
+	ClaimPreferring(quantity resource.Quantity, previous ResourceClaim) (claim ResourceClaim, satisfied bool, err error)
+}
+
+// claimSticky claims the resource preferring the devices of its previous claim given via WithStickyClaims.
+// ok is false if there is no previous claim of the resource or the plugin does not support stickiness.
+func (c *claimer) claimSticky(
+	plugin Plugin,
+	resourceName v1alpha1.ResourceName,
+	quantity resource.Quantity,
+	opts ClaimOptions,
+) (resourceClaim ResourceClaim, ok bool, err error) {
+	previous, ok := opts.Sticky[resourceName]
+	stickyPlugin, isSticky := plugin.(StickyPlugin)
+	if !ok || !isSticky {
+		return nil, false, nil
+	}
+
+	opts.result.setStrategy(resourceName, ClaimStrategySticky)
+	resourceClaim, satisfied, err := stickyPlugin.ClaimPreferring(quantity, previous)
+	if err != nil {
+		return nil, true, err
+	}
+	if !satisfied {
+		opts.result.warnf("%s: previously held devices not free, claimed other devices", resourceName)
+	}
+	return resourceClaim, true, nil
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Sticky Claims", func() {
+	It("should reclaim the previously held devices if they are free", func(ctx SpecContext) {
+		resourceClaimer, err := claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{{Bus: 0x17}, {Bus: 0x18}, {Bus: 0x97}, {Bus: 0x98}},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		resources := v1alpha1.ResourceList{"nvidia.com/gpu": resource.MustParse("2")}
+		previousDevices := []pci.Address{{Bus: 0x18}, {Bus: 0x97}}
+		previous := claim.Claims{"nvidia.com/gpu": gpu.NewGPUClaim(previousDevices)}
+
+		By("reclaiming the free previous devices")
+		claims, result, err := resourceClaimer.ClaimWithResult(ctx, resources, claim.WithStickyClaims(previous))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Strategies).To(HaveKeyWithValue(v1alpha1.ResourceName("nvidia.com/gpu"), claim.ClaimStrategySticky))
+		Expect(result.Warnings).To(BeEmpty())
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal(previousDevices))
+
+		By("claiming other devices while the previous ones are held")
+		others, result, err := resourceClaimer.ClaimWithResult(ctx, resources, claim.WithStickyClaims(previous))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Warnings).To(ConsistOf(ContainSubstring("previously held devices")))
+		Expect(others["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x17}, {Bus: 0x98}}))
+
+		By("reclaiming the previous devices after their release")
+		Expect(resourceClaimer.Release(ctx, claims)).To(Succeed())
+		Expect(resourceClaimer.Release(ctx, others)).To(Succeed())
+		claims, err = resourceClaimer.Claim(ctx, resources, claim.WithStickyClaims(previous))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims["nvidia.com/gpu"].(gpu.Claim).PCIAddresses()).To(Equal(previousDevices))
+	})
+})
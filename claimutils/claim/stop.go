@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"context"
+)
+
+// Stop stops the claimer without cancelling the context of Start. New requests are rejected with
+// ErrNotStarted at once, already queued ones are still processed until ctx is done. pending is the
+// number of queued claims and releases rejected because ctx was done before they were processed; it
+// is 0 if the claimer stopped cleanly. Plugins are shut down and Start returns once the loop exited.
+func (c *claimer) Stop(ctx context.Context) (pending int, err error) {
+	if err := c.ensureRunning(); err != nil {
+		return 0, err
+	}
+	c.stopAccepting()
+
+	req := stopReq{
+		ctx:     ctx,
+		pending: make(chan int, 1),
+	}
+	select {
+	case c.toStop <- req:
+	default:
+		// Another Stop is in progress
+		select {
+		case <-c.exited:
+			return 0, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	select {
+	case pending = <-req.pending:
+		return pending, ctx.Err()
+	case <-ctx.Done():
+		// The loop is busy, reject everything queued so far. Requests it receives in the meantime
+		// are still processed.
+		return c.rejectQueued(ErrNotStarted), ctx.Err()
+	}
+}
+
+// stop drains the queue, then shuts down the plugins. ctx is the context of Start.
+func (c *claimer) stop(ctx context.Context, req stopReq) {
+	req.pending <- c.drain(req.ctx)
+	close(c.shutdown)
+	c.shutdownPlugins(ctx)
+}
+
+// enqueue sends req to the loop, unless the claimer is stopping.
+func enqueue[R any](ctx context.Context, c *claimer, requests chan<- R, req R) error {
+	select {
+	case <-c.stopping:
+		return ErrNotStarted
+	default:
+	}
+
+	select {
+	case requests <- req:
+		return nil
+	case <-c.stopping:
+		return ErrNotStarted
+	case <-c.shutdown:
+		return ErrNotStarted
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// await waits for the result of a queued request. A request that got queued while the loop was
+// exiting is never received, it fails with ErrNotStarted.
+func await[R any](ctx context.Context, c *claimer, results <-chan R) (R, error) {
+	var zero R
+	select {
+	case res := <-results:
+		return res, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case <-c.exited:
+		select {
+		case res := <-results:
+			return res, nil
+		default:
+			return zero, ErrNotStarted
+		}
+	}
+}
+
+// stopAccepting rejects all further requests.
+func (c *claimer) stopAccepting() {
+	c.stopOnce.Do(func() {
+		close(c.stopping)
+	})
+}
+
+// drain processes the queued requests until none are left or ctx is done, then rejects the
+// remaining ones, returning their number.
+func (c *claimer) drain(ctx context.Context) int {
+	for {
+		select {
+		case <-ctx.Done():
+			return c.rejectQueued(ErrNotStarted)
+		default:
+		}
+
+		select {
+		case req := <-c.toExec:
+			c.handleExec(req)
+		case req := <-c.toClaim:
+			c.handleClaim(req)
+		case req := <-c.toRelease:
+			c.handleRelease(req)
+		default:
+			return 0
+		}
+	}
+}
+
+// rejectQueued replies err to all queued requests, returning the number of rejected claims and
+// releases. Requests still slipping in are failed by await once the loop exited. It is safe to call
+// outside the loop.
+func (c *claimer) rejectQueued(err error) int {
+	var rejected int
+	for {
+		select {
+		case req := <-c.toExec:
+			req.done <- err
+		case req := <-c.toClaim:
+			c.claimQueueDepth.Add(-1)
+			req.resultChan <- claimRes{err: err}
+			rejected++
+		case req := <-c.toRelease:
+			c.releaseQueueDepth.Add(-1)
+			req.resultChan <- err
+			rejected++
+		default:
+			return rejected
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Stop", func() {
+	oneUnit := v1alpha1.ResourceList{
+		"example.com/fake": resource.MustParse("1"),
+	}
+
+	var (
+		entered, unblock chan struct{}
+		resourceClaimer  interface {
+			claim.Claimer
+			Stop(ctx context.Context) (int, error)
+			QueueDepth() (int, int)
+		}
+		startErr chan error
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		entered, unblock = make(chan struct{}, 1), make(chan struct{})
+		var err error
+		resourceClaimer, err = claim.NewResourceClaimer(
+			log.FromContext(ctx),
+			&wedgingPlugin{
+				Plugin:  claimtest.NewFakePlugin("example.com/fake", 4),
+				entered: entered,
+				unblock: unblock,
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		startCtx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		startErr = make(chan error, 1)
+		go func() {
+			startErr <- resourceClaimer.Start(startCtx)
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+	})
+
+	// wedge claims in the background, returning the channel receiving their errors
+	wedge := func() <-chan error {
+		errs := make(chan error, 2)
+		for range 2 {
+			go func() {
+				_, err := resourceClaimer.Claim(context.Background(), oneUnit)
+				errs <- err
+			}()
+		}
+		Eventually(entered).Should(Receive())
+		Eventually(func() int {
+			claims, _ := resourceClaimer.QueueDepth()
+			return claims
+		}).Should(Equal(1))
+		return errs
+	}
+
+	It("should finish queued requests and reject new ones", func(ctx SpecContext) {
+		errs := wedge()
+
+		stopped := make(chan int, 1)
+		go func() {
+			defer GinkgoRecover()
+			pending, err := resourceClaimer.Stop(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			stopped <- pending
+		}()
+
+		By("rejecting claims once stopping")
+		Eventually(func() error {
+			_, err := resourceClaimer.Claim(ctx, oneUnit)
+			return err
+		}).Should(MatchError(claim.ErrNotStarted))
+
+		By("finishing the queued claims")
+		close(unblock)
+		Eventually(errs).Should(Receive(BeNil()))
+		Eventually(errs).Should(Receive(BeNil()))
+		Eventually(stopped).Should(Receive(BeZero()))
+		Eventually(startErr).Should(Receive(BeNil()))
+	})
+
+	It("should reject queued requests once the stop context is done", func(ctx SpecContext) {
+		errs := wedge()
+
+		stopCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		pending, err := resourceClaimer.Stop(stopCtx)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(pending).To(Equal(1))
+
+		close(unblock)
+		var results []error
+		for range 2 {
+			var err error
+			Eventually(errs).Should(Receive(&err))
+			results = append(results, err)
+		}
+		Expect(results).To(ConsistOf(BeNil(), MatchError(claim.ErrNotStarted)))
+		Eventually(startErr).Should(Receive(BeNil()))
+	})
+})
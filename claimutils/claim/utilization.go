@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim
+
+import (
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+)
+
+// Sample is the utilization of a resource at a point in time, in devices.
+type Sample struct {
+	Time  time.Time
+	Free  int64
+	Total int64
+}
+
+// utilizationRing keeps the latest samples of a resource, overwriting the oldest once full.
+type utilizationRing struct {
+	samples []Sample
+	next    int
+	full    bool
+}
+
+func newUtilizationRing(size int) *utilizationRing {
+	return &utilizationRing{samples: make([]Sample, size)}
+}
+
+func (r *utilizationRing) add(sample Sample) {
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// list returns the samples from oldest to latest.
+func (r *utilizationRing) list() []Sample {
+	if !r.full {
+		return append([]Sample(nil), r.samples[:r.next]...)
+	}
+	return append(append([]Sample(nil), r.samples[r.next:]...), r.samples[:r.next]...)
+}
+
+// UtilizationHistory returns the recorded samples of the resource from oldest to latest. Samples are
+// only recorded if ClaimerOptions.UtilizationSampleInterval is set and the plugin serving the resource
+// implements DeviceLister or wraps one, see deviceListerOf.
+func (c *claimer) UtilizationHistory(resourceName v1alpha1.ResourceName) []Sample {
+	c.utilizationMu.Lock()
+	defer c.utilizationMu.Unlock()
+
+	ring, ok := c.utilization[resourceName]
+	if !ok {
+		return nil
+	}
+	return ring.list()
+}
+
+// sampleUtilization records the utilization of all resources served by a DeviceLister.
+func (c *claimer) sampleUtilization() {
+	now := c.clock.Now()
+
+	samples := map[v1alpha1.ResourceName]Sample{}
+	for resourceName, plugin := range c.resources {
+		lister, ok := deviceListerOf(plugin)
+		if !ok {
+			continue
+		}
+
+		sample := Sample{Time: now}
+		for _, deviceID := range lister.ListDevices() {
+			sample.Total++
+			if free, err := lister.IsDeviceFree(deviceID); err == nil && free {
+				sample.Free++
+			}
+		}
+		samples[resourceName] = sample
+	}
+
+	c.utilizationMu.Lock()
+	defer c.utilizationMu.Unlock()
+
+	for resourceName, sample := range samples {
+		ring, ok := c.utilization[resourceName]
+		if !ok {
+			ring = newUtilizationRing(c.utilizationHistorySize)
+			c.utilization[resourceName] = ring
+		}
+		ring.add(sample)
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claim_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/ironcore-dev/ironcore/api/core/v1alpha1"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	testingclock "k8s.io/utils/clock/testing"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Utilization", func() {
+	It("should keep a bounded history of utilization samples", func(ctx SpecContext) {
+		fakeClock := testingclock.NewFakeClock(time.Now())
+
+		By("init claimer with utilization sampling")
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				Clock:                     fakeClock,
+				ReapInterval:              time.Hour,
+				UtilizationSampleInterval: time.Second,
+				UtilizationHistorySize:    3,
+			},
+			gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+				},
+			}, nil),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(resourceClaimer.Start(innerCtx)).To(Succeed())
+		}()
+		Expect(resourceClaimer.WaitUntilStarted(ctx)).To(Succeed())
+		Expect(resourceClaimer.UtilizationHistory("nvidia.com/gpu")).To(BeEmpty())
+
+		history := func() []claim.Sample {
+			return resourceClaimer.UtilizationHistory("nvidia.com/gpu")
+		}
+
+		By("accumulating samples")
+		fakeClock.Step(time.Second)
+		Eventually(history).Should(HaveLen(1))
+
+		_, err = resourceClaimer.Claim(ctx, v1alpha1.ResourceList{
+			"nvidia.com/gpu": resource.MustParse("1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClock.Step(time.Second)
+		Eventually(history).Should(HaveLen(2))
+		Expect(history()).To(Equal([]claim.Sample{
+			{Time: fakeClock.Now().Add(-time.Second), Free: 2, Total: 2},
+			{Time: fakeClock.Now(), Free: 1, Total: 2},
+		}))
+
+		By("bounding the history at the configured size")
+		for range 3 {
+			fakeClock.Step(time.Second)
+			now := fakeClock.Now()
+			Eventually(func() time.Time {
+				samples := history()
+				return samples[len(samples)-1].Time
+			}).Should(Equal(now))
+		}
+		samples := history()
+		Expect(samples).To(HaveLen(3))
+		Expect(samples[0].Time).To(Equal(fakeClock.Now().Add(-2 * time.Second)))
+		Expect(samples[2].Time).To(Equal(fakeClock.Now()))
+
+		By("recording nothing for unknown resources")
+		Expect(resourceClaimer.UtilizationHistory("unknown")).To(BeNil())
+	})
+
+	It("should sample the devices of wrapped plugins", func(ctx SpecContext) {
+		fakeClock := testingclock.NewFakeClock(time.Now())
+
+		resourceClaimer, err := claim.NewResourceClaimerWithOptions(
+			log.FromContext(ctx),
+			claim.ClaimerOptions{
+				Clock:                     fakeClock,
+				ReapInterval:              time.Hour,
+				UtilizationSampleInterval: time.Second,
+			},
+			claim.NewReservingPlugin(gpu.NewGPUClaimPlugin(log.FromContext(ctx), "nvidia.com/gpu", &mockReader{
+				devices: []pci.Address{
+					{},
+					{Function: 1},
+				},
+			}, nil), 1),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		startClaimer(ctx, resourceClaimer)
+
+		fakeClock.Step(time.Second)
+		Eventually(func() []claim.Sample {
+			return resourceClaimer.UtilizationHistory("nvidia.com/gpu")
+		}).Should(Equal([]claim.Sample{{Time: fakeClock.Now(), Free: 2, Total: 2}}))
+
+		free, err := resourceClaimer.IsDeviceFree(ctx, "nvidia.com/gpu", pci.Address{}.String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(free).To(BeTrue())
+	})
+})
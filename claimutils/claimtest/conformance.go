@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claimtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// overClaimQuantity is a quantity no conforming plugin is expected to satisfy.
+var overClaimQuantity = resource.MustParse("1Ei")
+
+type foreignClaim struct{}
+
+// RunPluginConformance exercises the claim.Plugin contract against plugins created by newPlugin.
+// newPlugin must return a fresh, not yet initialized plugin that is able to satisfy a claim of one unit.
+func RunPluginConformance(t *testing.T, newPlugin func() claim.Plugin) {
+	t.Helper()
+
+	initPlugin := func(t *testing.T) claim.Plugin {
+		t.Helper()
+
+		plugin := newPlugin()
+		if err := plugin.Init(); err != nil {
+			t.Fatalf("Init: %v", err)
+		}
+		return plugin
+	}
+
+	t.Run("Name", func(t *testing.T) {
+		if newPlugin().Name() == "" {
+			t.Fatal("expected a non-empty plugin name")
+		}
+	})
+
+	t.Run("DoubleInit", func(t *testing.T) {
+		plugin := initPlugin(t)
+		if err := plugin.Init(); err != nil {
+			t.Fatalf("second Init: %v", err)
+		}
+
+		if plugin.CanClaim(overClaimQuantity) {
+			t.Fatal("expected second Init to not inflate capacity")
+		}
+		if !plugin.CanClaim(resource.MustParse("1")) {
+			t.Fatal("expected plugin to be able to claim after second Init")
+		}
+	})
+
+	t.Run("OverClaim", func(t *testing.T) {
+		plugin := initPlugin(t)
+		if plugin.CanClaim(overClaimQuantity) {
+			t.Fatal("expected CanClaim to report false for an over-claim")
+		}
+
+		resourceClaim, err := plugin.Claim(overClaimQuantity)
+		if !errors.Is(err, claim.ErrInsufficientResources) {
+			t.Fatalf("expected %v, got %v", claim.ErrInsufficientResources, err)
+		}
+		if resourceClaim != nil {
+			t.Fatalf("expected no claim on over-claim, got %v", resourceClaim)
+		}
+	})
+
+	t.Run("ZeroQuantity", func(t *testing.T) {
+		plugin := initPlugin(t)
+		if !plugin.CanClaim(resource.MustParse("0")) {
+			t.Fatal("expected CanClaim to report true for a zero-quantity claim")
+		}
+
+		resourceClaim, err := plugin.Claim(resource.MustParse("0"))
+		if err != nil {
+			t.Fatalf("Claim: %v", err)
+		}
+		if err := plugin.Release(resourceClaim); err != nil {
+			t.Fatalf("Release: %v", err)
+		}
+	})
+
+	t.Run("ClaimAndRelease", func(t *testing.T) {
+		plugin := initPlugin(t)
+		quantity := resource.MustParse("1")
+		if !plugin.CanClaim(quantity) {
+			t.Fatal("expected CanClaim to report true")
+		}
+
+		resourceClaim, err := plugin.Claim(quantity)
+		if err != nil {
+			t.Fatalf("Claim: %v", err)
+		}
+		if err := plugin.Release(resourceClaim); err != nil {
+			t.Fatalf("Release: %v", err)
+		}
+
+		resourceClaim, err = plugin.Claim(quantity)
+		if err != nil {
+			t.Fatalf("Claim after Release: %v", err)
+		}
+		if err := plugin.Release(resourceClaim); err != nil {
+			t.Fatalf("Release: %v", err)
+		}
+	})
+
+	t.Run("ReleaseInvalidClaim", func(t *testing.T) {
+		plugin := initPlugin(t)
+		if err := plugin.Release(nil); !errors.Is(err, claim.ErrInvalidResourceClaim) {
+			t.Fatalf("expected %v releasing nil claim, got %v", claim.ErrInvalidResourceClaim, err)
+		}
+		if err := plugin.Release(foreignClaim{}); !errors.Is(err, claim.ErrInvalidResourceClaim) {
+			t.Fatalf("expected %v releasing foreign claim, got %v", claim.ErrInvalidResourceClaim, err)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claimtest
+
+import (
+	"sync"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// FakeClaim is the claim issued by FakePlugin if no ClaimFunc is set.
+type FakeClaim struct {
+	Quantity resource.Quantity
+}
+
+// FakePlugin is a claim.Plugin with programmable behavior for testing code composing plugins.
+// By default it issues FakeClaims of up to Total units in total and reports them as a
+// claim.CapacityPlugin. A set func replaces the default
+// behavior of its method, Claimed only accounts for FakeClaims issued by default. Funcs must be set
+// before the plugin is used.
+type FakePlugin struct {
+	PluginName string
+	Total      int64
+
+	CanClaimFunc func(quantity resource.Quantity) bool
+	ClaimFunc    func(quantity resource.Quantity) (claim.ResourceClaim, error)
+	ReleaseFunc  func(resourceClaim claim.ResourceClaim) error
+	InitFunc     func() error
+
+	mu          sync.Mutex
+	outstanding map[*FakeClaim]struct{}
+	claimed     int64
+	calls       FakePluginCalls
+}
+
+// FakePluginCalls counts the calls of the methods of a FakePlugin.
+type FakePluginCalls struct {
+	CanClaim int
+	Claim    int
+	Release  int
+	Init     int
+}
+
+// NewFakePlugin returns a FakePlugin serving the resource of the given name with the given capacity.
+func NewFakePlugin(name string, capacity int64) *FakePlugin {
+	return &FakePlugin{
+		PluginName: name,
+		Total:      capacity,
+	}
+}
+
+func (p *FakePlugin) Name() string {
+	return p.PluginName
+}
+
+// count increments a call counter. Funcs are called without holding the lock, so they may call
+// back into the plugin, e.g. to delegate to its default behavior.
+func (p *FakePlugin) count(counter *int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	*counter++
+}
+
+func (p *FakePlugin) Init() error {
+	p.count(&p.calls.Init)
+	if p.InitFunc != nil {
+		return p.InitFunc()
+	}
+	return nil
+}
+
+func (p *FakePlugin) CanClaim(quantity resource.Quantity) bool {
+	p.count(&p.calls.CanClaim)
+	if p.CanClaimFunc != nil {
+		return p.CanClaimFunc(quantity)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.canClaim(quantity)
+}
+
+func (p *FakePlugin) canClaim(quantity resource.Quantity) bool {
+	return quantity.Sign() >= 0 && quantity.Value() <= p.Total-p.claimed
+}
+
+func (p *FakePlugin) Claim(quantity resource.Quantity) (claim.ResourceClaim, error) {
+	p.count(&p.calls.Claim)
+	if p.ClaimFunc != nil {
+		return p.ClaimFunc(quantity)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.canClaim(quantity) {
+		return nil, claim.ErrInsufficientResources
+	}
+
+	resourceClaim := &FakeClaim{Quantity: quantity}
+	if p.outstanding == nil {
+		p.outstanding = map[*FakeClaim]struct{}{}
+	}
+	p.outstanding[resourceClaim] = struct{}{}
+	p.claimed += quantity.Value()
+	return resourceClaim, nil
+}
+
+func (p *FakePlugin) Release(resourceClaim claim.ResourceClaim) error {
+	p.count(&p.calls.Release)
+	if p.ReleaseFunc != nil {
+		return p.ReleaseFunc(resourceClaim)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fakeClaim, ok := resourceClaim.(*FakeClaim)
+	if !ok {
+		return claim.ErrInvalidResourceClaim
+	}
+	if _, ok := p.outstanding[fakeClaim]; !ok {
+		return claim.ErrInvalidResourceClaim
+	}
+
+	delete(p.outstanding, fakeClaim)
+	p.claimed -= fakeClaim.Quantity.Value()
+	return nil
+}
+
+// Capacity returns Total as a claim.CapacityPlugin.
+func (p *FakePlugin) Capacity() resource.Quantity {
+	return *resource.NewQuantity(p.Total, resource.DecimalSI)
+}
+
+// Available returns the units not held by claims the plugin issued itself as a claim.CapacityPlugin.
+func (p *FakePlugin) Available() resource.Quantity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return *resource.NewQuantity(p.Total-p.claimed, resource.DecimalSI)
+}
+
+// Claimed returns the units held by claims the plugin issued itself.
+func (p *FakePlugin) Claimed() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.claimed
+}
+
+// Calls returns the number of calls of each method so far.
+func (p *FakePlugin) Calls() FakePluginCalls {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.calls
+}
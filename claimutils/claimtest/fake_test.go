@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package claimtest_test
+
+import (
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+)
+
+func TestFakePluginConformance(t *testing.T) {
+	claimtest.RunPluginConformance(t, func() claim.Plugin {
+		return claimtest.NewFakePlugin("example.com/fake", 2)
+	})
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpu
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+var (
+	ErrNoDeviceMinor = errors.New("no device minor reported")
+)
+
+// AccessChecker reports whether a discovered device can actually be used by the process,
+// e.g. because its device node is not permitted by the cgroup device allowlist.
+type AccessChecker interface {
+	Accessible(address pci.Address) (bool, error)
+}
+
+// AccessCheckerFunc adapts a function to an AccessChecker.
+type AccessCheckerFunc func(address pci.Address) (bool, error)
+
+func (f AccessCheckerFunc) Accessible(address pci.Address) (bool, error) {
+	return f(address)
+}
+
+// NewNvidiaAccessChecker returns an AccessChecker resolving the device node of a gpu via the
+// nvidia driver information in procRoot (usually /proc) and opening it below devRoot (usually /dev).
+// Opening the node is subject to the cgroup device allowlist, unlike checking its permissions.
+func NewNvidiaAccessChecker(procRoot, devRoot string) AccessChecker {
+	return AccessCheckerFunc(func(address pci.Address) (bool, error) {
+		minor, err := nvidiaDeviceMinor(procRoot, address)
+		if err != nil {
+			return false, err
+		}
+
+		file, err := os.OpenFile(filepath.Join(devRoot, fmt.Sprintf("nvidia%d", minor)), os.O_RDWR, 0)
+		if err != nil {
+			if os.IsNotExist(err) || os.IsPermission(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		_ = file.Close()
+
+		return true, nil
+	})
+}
+
+func nvidiaDeviceMinor(procRoot string, address pci.Address) (int, error) {
+	path := filepath.Join(procRoot, "driver", "nvidia", "gpus", address.String(), "information")
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read nvidia information of %s: %w", address, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(key) != "Device Minor" {
+			continue
+		}
+
+		minor, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return 0, fmt.Errorf("invalid device minor of %s: %w", address, err)
+		}
+		return minor, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read nvidia information of %s: %w", address, err)
+	}
+
+	return 0, fmt.Errorf("%s: %w", address, ErrNoDeviceMinor)
+}
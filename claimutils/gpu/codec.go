@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpu
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+// ClaimCodec is the claim.ClaimCodec for GPU claims.
+type ClaimCodec struct{}
+
+type encodedClaim struct {
+	PCIAddresses []string `json:"pciAddresses"`
+}
+
+func (ClaimCodec) EncodeClaim(resourceClaim claim.ResourceClaim) (json.RawMessage, error) {
+	gpu, ok := claim.UnwrapClaim(resourceClaim).(Claim)
+	if !ok {
+		return nil, claim.ErrInvalidResourceClaim
+	}
+
+	encoded := encodedClaim{PCIAddresses: make([]string, 0, len(gpu.PCIAddresses()))}
+	for _, address := range gpu.PCIAddresses() {
+		encoded.PCIAddresses = append(encoded.PCIAddresses, address.String())
+	}
+
+	return json.Marshal(encoded)
+}
+
+func (ClaimCodec) DecodeClaim(data json.RawMessage) (claim.ResourceClaim, error) {
+	encoded := encodedClaim{}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal gpu claim: %w", err)
+	}
+
+	return ClaimCodec{}.DecodeStatus(encoded.PCIAddresses)
+}
+
+// DecodeStatus decodes a gpu claim from the pci addresses of its status devices.
+func (ClaimCodec) DecodeStatus(devices []string) (claim.ResourceClaim, error) {
+	addresses := make([]pci.Address, 0, len(devices))
+	for _, device := range devices {
+		address, err := pci.ParseAddress(device)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return NewGPUClaim(addresses), nil
+}
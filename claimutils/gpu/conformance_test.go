@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpu_test
+
+import (
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestGPUPluginConformance(t *testing.T) {
+	claimtest.RunPluginConformance(t, func() claim.Plugin {
+		return gpu.NewGPUClaimPlugin(log.Log, "nvidia.com/gpu", &MockReader{
+			devices: []pci.Address{
+				{},
+				{Function: 1},
+			},
+		}, nil)
+	})
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpu
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DriftReport is the result of comparing the managed devices with the devices reported by the reader.
+type DriftReport struct {
+	CheckedAt time.Time
+	// MissingClaimed are claimed devices the reader no longer reports.
+	MissingClaimed []pci.Address
+	// Missing are free devices the reader no longer reports.
+	Missing []pci.Address
+	// Unmanaged are devices the reader reports that are not managed by the plugin.
+	Unmanaged []pci.Address
+	// Err is set if the reader failed.
+	Err error
+}
+
+func (r DriftReport) HasDiscrepancies() bool {
+	return len(r.MissingClaimed) > 0 || len(r.Missing) > 0 || len(r.Unmanaged) > 0
+}
+
+// DriftChecker is implemented by the gpu plugin to detect discrepancies between its devices and the host.
+type DriftChecker interface {
+	// CheckDrift re-reads the devices and compares them with the managed ones.
+	CheckDrift() DriftReport
+	// LastDriftReport returns the report of the last check, ok is false if no check ran yet.
+	LastDriftReport() (report DriftReport, ok bool)
+	// Start runs CheckDrift periodically until ctx is done, if a DriftCheckInterval is configured.
+	Start(ctx context.Context)
+}
+
+func (g *gpuClaimPlugin) CheckDrift() DriftReport {
+	devices, _, _, err := g.readDevices()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	report := DriftReport{CheckedAt: time.Now()}
+	if err != nil {
+		report.Err = err
+	} else {
+		for address, status := range g.devices {
+			if slices.Contains(devices, address) {
+				continue
+			}
+			if status == ClaimStatusClaimed {
+				report.MissingClaimed = append(report.MissingClaimed, address)
+			} else {
+				report.Missing = append(report.Missing, address)
+			}
+		}
+		for _, address := range devices {
+			if _, ok := g.devices[address]; !ok && !g.inaccessible[address] {
+				report.Unmanaged = append(report.Unmanaged, address)
+			}
+		}
+		slices.SortFunc(report.MissingClaimed, pci.Address.Compare)
+		slices.SortFunc(report.Missing, pci.Address.Compare)
+		slices.SortFunc(report.Unmanaged, pci.Address.Compare)
+	}
+
+	switch {
+	case report.Err != nil:
+		g.log.Error(report.Err, "Failed to check device drift")
+	case report.HasDiscrepancies():
+		g.log.Info("Detected device drift",
+			"missingClaimed", report.MissingClaimed,
+			"missing", report.Missing,
+			"unmanaged", report.Unmanaged,
+		)
+	}
+
+	g.lastDriftReport = &report
+	return report
+}
+
+func (g *gpuClaimPlugin) LastDriftReport() (DriftReport, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.lastDriftReport == nil {
+		return DriftReport{}, false
+	}
+	return *g.lastDriftReport, true
+}
+
+func (g *gpuClaimPlugin) Start(ctx context.Context) {
+	if g.driftCheckInterval <= 0 {
+		return
+	}
+
+	wait.UntilWithContext(ctx, func(context.Context) {
+		g.CheckDrift()
+	}, g.driftCheckInterval)
+}
@@ -4,8 +4,13 @@
 package gpu
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/ironcore-dev/provider-utils/claimutils/claim"
@@ -13,6 +18,19 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+var (
+	ErrIndexOutOfRange      = errors.New("device index out of range")
+	ErrDeviceAlreadyClaimed = errors.New("device already claimed")
+	ErrDeviceNotManaged     = errors.New("device not managed by plugin")
+	ErrNoDevicesDiscovered  = errors.New("no devices discovered")
+)
+
+// IndexClaimer is implemented by plugins that can claim devices by their ordinal index.
+// Indices refer to the managed devices sorted by pci address.
+type IndexClaimer interface {
+	ClaimByIndex(indices []int) (claim.ResourceClaim, error)
+}
+
 type Claim interface {
 	claim.ResourceClaim
 	PCIAddresses() []pci.Address
@@ -32,6 +50,14 @@ func (c gpuClaim) PCIAddresses() []pci.Address {
 	return c.devices
 }
 
+func (c gpuClaim) StatusDevices() []string {
+	devices := make([]string, 0, len(c.devices))
+	for _, device := range c.devices {
+		devices = append(devices, device.String())
+	}
+	return devices
+}
+
 type ClaimStatus bool
 
 const (
@@ -39,71 +65,545 @@ const (
 	ClaimStatusClaimed ClaimStatus = false
 )
 
+// AllAvailable returns the sentinel quantity claiming all currently free devices.
+func AllAvailable() resource.Quantity {
+	return *resource.NewQuantity(-1, resource.DecimalSI)
+}
+
+// IsAllAvailable reports whether the quantity is the AllAvailable sentinel.
+func IsAllAvailable(quantity resource.Quantity) bool {
+	return quantity.Value() == -1
+}
+
+// Options defines options to initialize the gpu claim plugin.
+type Options struct {
+	// MinAllAvailable is the number of free devices required for an AllAvailable claim. Defaults to 1.
+	MinAllAvailable int64
+	// AccessChecker, if set, excludes discovered devices the process cannot access.
+	AccessChecker AccessChecker
+	// DriftCheckInterval, if set, is the interval Start compares the managed devices with the reader in.
+	DriftCheckInterval time.Duration
+	// TelemetrySource, if set, is read by the LeastLoaded selection policy. Without it LeastLoaded
+	// takes devices in address order.
+	TelemetrySource pci.TelemetrySource
+	// TelemetryTTL is the duration the telemetry of a device is reused for. Defaults to 10 seconds.
+	TelemetryTTL time.Duration
+}
+
+func (o *Options) Defaults() {
+	if o.MinAllAvailable <= 0 {
+		o.MinAllAvailable = 1
+	}
+
+	if o.TelemetryTTL <= 0 {
+		o.TelemetryTTL = 10 * time.Second
+	}
+}
+
 func NewGPUClaimPlugin(log logr.Logger, name string, reader pci.Reader, preClaimed []pci.Address) claim.Plugin {
+	return NewGPUClaimPluginWithOptions(log, name, reader, preClaimed, Options{})
+}
+
+func NewGPUClaimPluginWithOptions(
+	log logr.Logger,
+	name string,
+	reader pci.Reader,
+	preClaimed []pci.Address,
+	opts Options,
+) claim.Plugin {
+	opts.Defaults()
 
 	return &gpuClaimPlugin{
-		name:       name,
-		log:        log,
-		pciReader:  reader,
-		devices:    map[pci.Address]ClaimStatus{},
-		preClaimed: preClaimed,
+		name:            name,
+		log:             log,
+		pciReader:       reader,
+		devices:         map[pci.Address]ClaimStatus{},
+		numaNodes:       map[pci.Address]int{},
+		switches:        map[pci.Address]pci.Address{},
+		preClaimed:      preClaimed,
+		minAllAvailable: opts.MinAllAvailable,
+		accessChecker:   opts.AccessChecker,
+		telemetrySource: opts.TelemetrySource,
+		telemetryTTL:    opts.TelemetryTTL,
+		telemetry:       map[pci.Address]telemetrySample{},
+
+		driftCheckInterval: opts.DriftCheckInterval,
 	}
 }
 
 type gpuClaimPlugin struct {
 	name       string
 	log        logr.Logger
-	devices    map[pci.Address]ClaimStatus
 	pciReader  pci.Reader
 	preClaimed []pci.Address
-}
 
-func (g *gpuClaimPlugin) canClaim(quantity resource.Quantity) bool {
-	requested := quantity.Value()
+	// mu guards the device state against the drift check, plugin calls are serialized by the claimer.
+	mu           sync.Mutex
+	devices      map[pci.Address]ClaimStatus
+	numaNodes    map[pci.Address]int
+	switches     map[pci.Address]pci.Address
+	indexed      []pci.Address
+	inaccessible map[pci.Address]bool
+
+	minAllAvailable int64
+	accessChecker   AccessChecker
+	telemetrySource pci.TelemetrySource
+	telemetryTTL    time.Duration
+	// telemetryMu guards telemetry, which is read without holding mu.
+	telemetryMu sync.Mutex
+	telemetry   map[pci.Address]telemetrySample
+
+	driftCheckInterval time.Duration
+	lastDriftReport    *DriftReport
+}
 
+func (g *gpuClaimPlugin) free() int64 {
 	var free int64
 	for _, claimed := range g.devices {
 		if claimed == ClaimStatusFree {
 			free++
 		}
 	}
+	return free
+}
+
+func (g *gpuClaimPlugin) canClaim(quantity resource.Quantity) bool {
+	free := g.free()
+	if IsAllAvailable(quantity) {
+		g.log.V(2).Info("Try to claim all available devices", "free", free, "minimum", g.minAllAvailable)
+		return free >= g.minAllAvailable
+	}
+
+	requested, err := claim.Int64Value(quantity)
+	if err != nil {
+		g.log.V(2).Info("Requested quantity overflows", "quantity", quantity.String())
+		return false
+	}
 	g.log.V(2).Info("Try to claim devices ", "free", free, "requested", requested)
 
-	return free >= requested
+	return requested >= 0 && free >= requested
 }
 
 func (g *gpuClaimPlugin) CanClaim(quantity resource.Quantity) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	return g.canClaim(quantity)
 }
 
-func (g *gpuClaimPlugin) Claim(quantity resource.Quantity) (claim.ResourceClaim, error) {
+// requested returns the number of devices to claim for the quantity, failing if they are not available.
+func (g *gpuClaimPlugin) requested(quantity resource.Quantity) (int64, error) {
+	if len(g.devices) == 0 {
+		return 0, fmt.Errorf("%w: %w", ErrNoDevicesDiscovered, claim.ErrInsufficientResources)
+	}
+
+	if _, err := claim.Int64Value(quantity); err != nil {
+		return 0, fmt.Errorf("requested %w: %w", err, claim.ErrInsufficientResources)
+	}
+
 	if !g.canClaim(quantity) {
-		return nil, claim.ErrInsufficientResources
+		return 0, fmt.Errorf("requested %s of %d free devices: %w", quantity.String(), g.free(), claim.ErrInsufficientResources)
 	}
 
-	requested := quantity.Value()
+	if IsAllAvailable(quantity) {
+		return g.free(), nil
+	}
+	return quantity.Value(), nil
+}
 
-	gClaim := &gpuClaim{}
-	for device, claimed := range g.devices {
-		if int64(len(gClaim.devices)) == requested {
-			break
+// Claim claims the requested number of free devices in address order.
+func (g *gpuClaimPlugin) Claim(quantity resource.Quantity) (claim.ResourceClaim, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	requested, err := g.requested(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	gClaim, err := g.claimDevices(g.freeDevices(), requested)
+	if err != nil {
+		return nil, err
+	}
+	g.log.V(2).Info("Claimed devices", "devices", gClaim.devices)
+
+	return gClaim, nil
+}
+
+// ClaimOnNUMANode claims like Claim, preferring free devices of the given NUMA node. Devices of
+// other or unknown nodes are claimed in address order if the node has too few free devices.
+func (g *gpuClaimPlugin) ClaimOnNUMANode(quantity resource.Quantity, node int) (claim.ResourceClaim, bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	requested, err := g.requested(quantity)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var onNode, offNode []pci.Address
+	for _, device := range g.freeDevices() {
+		if deviceNode, ok := g.numaNodes[device]; ok && deviceNode == node {
+			onNode = append(onNode, device)
+		} else {
+			offNode = append(offNode, device)
 		}
+	}
 
-		if claimed == ClaimStatusFree {
-			g.devices[device] = ClaimStatusClaimed
-			gClaim.devices = append(gClaim.devices, device)
+	gClaim, err := g.claimDevices(slices.Concat(onNode, offNode), requested)
+	if err != nil {
+		return nil, false, err
+	}
+
+	satisfied := int64(len(onNode)) >= requested
+	g.log.V(2).Info("Claimed devices on numa node", "node", node, "devices", gClaim.devices, "satisfied", satisfied)
+
+	return gClaim, satisfied, nil
+}
+
+// ClaimWithPolicy claims like Claim, grouping the devices by NUMA node. Pack takes all devices from
+// the group with the fewest free devices that can satisfy the claim, and only if none can, takes the
+// group with the most free devices and continues with the rest. Spread takes each device from the
+// group with the most free devices. Ties are broken by the lower node, devices of a group are taken in
+// address order. LeastLoaded takes the free devices with the
+// lowest utilization, then temperature, reported by Options.TelemetrySource.
+func (g *gpuClaimPlugin) ClaimWithPolicy(quantity resource.Quantity, policy claim.SelectionPolicy) (claim.ResourceClaim, error) {
+	if policy == claim.SelectionPolicyLeastLoaded {
+		return g.claimLeastLoaded(quantity)
+	}
+	if policy != claim.SelectionPolicyPack && policy != claim.SelectionPolicySpread {
+		return g.Claim(quantity)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	requested, err := g.requested(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[int][]pci.Address{}
+	for _, device := range g.freeDevices() {
+		node, ok := g.numaNodes[device]
+		if !ok {
+			node = -1
 		}
+		groups[node] = append(groups[node], device)
 	}
 
-	g.log.V(2).Info("Claimed devices", "devices", gClaim.devices)
+	candidates := make([]pci.Address, 0, requested)
+	for int64(len(candidates)) < requested {
+		remaining := int(requested) - len(candidates)
+		node := selectGroup(groups, policy, remaining)
+		take := 1
+		if policy == claim.SelectionPolicyPack {
+			take = min(remaining, len(groups[node]))
+		}
+		candidates = append(candidates, groups[node][:take]...)
+		groups[node] = groups[node][take:]
+	}
+
+	gClaim, err := g.claimDevices(candidates, requested)
+	if err != nil {
+		return nil, err
+	}
+	g.log.V(2).Info("Claimed devices by policy", "policy", policy, "devices", gClaim.devices)
 
 	return gClaim, nil
 }
 
+// ClaimAvoiding claims like Claim, preferring free devices sharing neither the NUMA node nor the PCIe
+// switch, see pci.DeviceInfo.Parent, with the devices of the given claims. If there are too few,
+// devices sharing one of them and then both are claimed, each in address order. Devices of unknown
+// locality are never considered co-located.
+func (g *gpuClaimPlugin) ClaimAvoiding(quantity resource.Quantity, avoid []claim.ResourceClaim) (claim.ResourceClaim, bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	requested, err := g.requested(quantity)
+	if err != nil {
+		return nil, false, err
+	}
+
+	avoidNodes := map[int]bool{}
+	avoidSwitches := map[pci.Address]bool{}
+	for _, resourceClaim := range avoid {
+		gpu, ok := claim.UnwrapClaim(resourceClaim).(Claim)
+		if !ok {
+			continue
+		}
+
+		for _, device := range gpu.PCIAddresses() {
+			if node, ok := g.numaNodes[device]; ok {
+				avoidNodes[node] = true
+			}
+			if parent, ok := g.switches[device]; ok {
+				avoidSwitches[parent] = true
+			}
+		}
+	}
+
+	// candidates are the free devices grouped by the number of localities shared with the avoided devices
+	var candidates [3][]pci.Address
+	for _, device := range g.freeDevices() {
+		shared := 0
+		if node, ok := g.numaNodes[device]; ok && avoidNodes[node] {
+			shared++
+		}
+		if parent, ok := g.switches[device]; ok && avoidSwitches[parent] {
+			shared++
+		}
+		candidates[shared] = append(candidates[shared], device)
+	}
+
+	gClaim, err := g.claimDevices(slices.Concat(candidates[:]...), requested)
+	if err != nil {
+		return nil, false, err
+	}
+
+	satisfied := int64(len(candidates[0])) >= requested
+	g.log.V(2).Info("Claimed devices avoiding other claims", "devices", gClaim.devices, "satisfied", satisfied)
+
+	return gClaim, satisfied, nil
+}
+
+// ClaimPreferring claims like Claim, preferring the free devices of the previous claim. Other free devices
+// are claimed in address order if the previous devices are taken or too few.
+func (g *gpuClaimPlugin) ClaimPreferring(quantity resource.Quantity, previous claim.ResourceClaim) (claim.ResourceClaim, bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	requested, err := g.requested(quantity)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var previousDevices []pci.Address
+	if gpu, ok := claim.UnwrapClaim(previous).(Claim); ok {
+		previousDevices = gpu.PCIAddresses()
+	}
+
+	var preferred, others []pci.Address
+	for _, device := range g.freeDevices() {
+		if slices.Contains(previousDevices, device) {
+			preferred = append(preferred, device)
+		} else {
+			others = append(others, device)
+		}
+	}
+
+	gClaim, err := g.claimDevices(slices.Concat(preferred, others), requested)
+	if err != nil {
+		return nil, false, err
+	}
+
+	satisfied := int64(len(preferred)) >= min(requested, int64(len(previousDevices)))
+	g.log.V(2).Info("Claimed devices preferring previous ones", "previous", previousDevices, "devices", gClaim.devices, "satisfied", satisfied)
+
+	return gClaim, satisfied, nil
+}
+
+func (g *gpuClaimPlugin) claimLeastLoaded(quantity resource.Quantity) (claim.ResourceClaim, error) {
+	g.mu.Lock()
+	free := g.freeDevices()
+	g.mu.Unlock()
+
+	// Telemetry is read without holding mu, so slow sources don't block the drift check
+	load := g.deviceLoad(free)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	requested, err := g.requested(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Devices may have been claimed or removed in the meantime
+	free = g.freeDevices()
+
+	// Devices without telemetry sort last, the stable sort keeps address order among equals.
+	slices.SortStableFunc(free, func(a, b pci.Address) int {
+		return compareLoad(load, a, b)
+	})
+
+	gClaim, err := g.claimDevices(free, requested)
+	if err != nil {
+		return nil, err
+	}
+	g.log.V(2).Info("Claimed least loaded devices", "devices", gClaim.devices)
+
+	return gClaim, nil
+}
+
+// freeDevices returns the free devices in address order.
+func (g *gpuClaimPlugin) freeDevices() []pci.Address {
+	var free []pci.Address
+	for _, device := range g.indexed {
+		if g.devices[device] == ClaimStatusFree {
+			free = append(free, device)
+		}
+	}
+	return free
+}
+
+// claimDevices claims the first n free devices of the candidates, which the selection strategies
+// order by preference. It claims nothing if there are fewer than n.
+func (g *gpuClaimPlugin) claimDevices(candidates []pci.Address, n int64) (*gpuClaim, error) {
+	selected := make([]pci.Address, 0, n)
+	for _, device := range candidates {
+		if int64(len(selected)) == n {
+			break
+		}
+		if g.devices[device] == ClaimStatusFree && !slices.Contains(selected, device) {
+			selected = append(selected, device)
+		}
+	}
+	if int64(len(selected)) < n {
+		return nil, fmt.Errorf("requested %d of %d free devices: %w", n, len(selected), claim.ErrInsufficientResources)
+	}
+
+	for _, device := range selected {
+		g.devices[device] = ClaimStatusClaimed
+	}
+	return &gpuClaim{devices: selected}, nil
+}
+
+// compareLoad orders devices by utilization, then temperature. Unknown values sort after known ones.
+func compareLoad(load map[pci.Address]pci.Telemetry, a, b pci.Address) int {
+	loadA, okA := load[a]
+	loadB, okB := load[b]
+	if !okA || !okB {
+		return cmp.Compare(boolRank(!okA), boolRank(!okB))
+	}
+	if c := compareKnown(loadA.UtilizationPercent, loadB.UtilizationPercent); c != 0 {
+		return c
+	}
+	return compareKnown(loadA.TemperatureC, loadB.TemperatureC)
+}
+
+// compareKnown compares two telemetry values, negative values being unknown.
+func compareKnown(a, b float64) int {
+	if a < 0 || b < 0 {
+		return cmp.Compare(boolRank(a < 0), boolRank(b < 0))
+	}
+	return cmp.Compare(a, b)
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// selectGroup returns the node of the group with free devices to take the next devices from. For Pack
+// it is the smallest group holding the remaining devices, else the largest group, for Spread the
+// largest group.
+func selectGroup(groups map[int][]pci.Address, policy claim.SelectionPolicy, remaining int) int {
+	selected, selectedFree := 0, 0
+	for _, node := range slices.Sorted(maps.Keys(groups)) {
+		free := len(groups[node])
+		if free == 0 {
+			continue
+		}
+
+		selectedFits, fits := selectedFree >= remaining, free >= remaining
+		switch {
+		case selectedFree == 0:
+			selected, selectedFree = node, free
+		case policy == claim.SelectionPolicyPack && fits && (!selectedFits || free < selectedFree):
+			selected, selectedFree = node, free
+		case policy == claim.SelectionPolicyPack && !fits && !selectedFits && free > selectedFree:
+			selected, selectedFree = node, free
+		case policy == claim.SelectionPolicySpread && free > selectedFree:
+			selected, selectedFree = node, free
+		}
+	}
+	return selected
+}
+
+func (g *gpuClaimPlugin) ClaimByIndex(indices []int) (claim.ResourceClaim, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	requested := make([]pci.Address, 0, len(indices))
+	for _, index := range indices {
+		if index < 0 || index >= len(g.indexed) {
+			return nil, fmt.Errorf("index %d of %d devices: %w", index, len(g.indexed), ErrIndexOutOfRange)
+		}
+
+		device := g.indexed[index]
+		if g.devices[device] != ClaimStatusFree || slices.Contains(requested, device) {
+			return nil, fmt.Errorf("index %d (%s): %w", index, device, ErrDeviceAlreadyClaimed)
+		}
+		requested = append(requested, device)
+	}
+
+	for _, device := range requested {
+		g.devices[device] = ClaimStatusClaimed
+	}
+
+	g.log.V(2).Info("Claimed devices by index", "indices", indices, "devices", requested)
+
+	return &gpuClaim{devices: requested}, nil
+}
+
+func (g *gpuClaimPlugin) RestoreClaim(resourceClaim claim.ResourceClaim) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gpu, ok := resourceClaim.(Claim)
+	if !ok {
+		return fmt.Errorf("%T is no gpu claim: %w", resourceClaim, claim.ErrInvalidResourceClaim)
+	}
+
+	for _, pciAddress := range gpu.PCIAddresses() {
+		status, existing := g.devices[pciAddress]
+		switch {
+		case !existing:
+			return fmt.Errorf("%s: %w", pciAddress, ErrDeviceNotManaged)
+		case status == ClaimStatusClaimed:
+			return fmt.Errorf("%s: %w", pciAddress, ErrDeviceAlreadyClaimed)
+		}
+	}
+
+	for _, pciAddress := range gpu.PCIAddresses() {
+		g.log.V(2).Info("Restored claimed device", "pciAddress", pciAddress)
+		g.devices[pciAddress] = ClaimStatusClaimed
+	}
+
+	return nil
+}
+
+// ClaimNUMANodes returns the known NUMA nodes of the devices of the claim in ascending order.
+func (g *gpuClaimPlugin) ClaimNUMANodes(resourceClaim claim.ResourceClaim) []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gpu, ok := claim.UnwrapClaim(resourceClaim).(Claim)
+	if !ok {
+		return nil
+	}
+
+	var nodes []int
+	for _, device := range gpu.PCIAddresses() {
+		if node, ok := g.numaNodes[device]; ok && !slices.Contains(nodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
 func (g *gpuClaimPlugin) Release(resourceClaim claim.ResourceClaim) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	gpu, ok := resourceClaim.(Claim)
 	if !ok {
-		return claim.ErrInvalidResourceClaim
+		return fmt.Errorf("%T is no gpu claim: %w", resourceClaim, claim.ErrInvalidResourceClaim)
 	}
 
 	pciAddresses := gpu.PCIAddresses()
@@ -125,16 +625,37 @@ func (g *gpuClaimPlugin) Init() error {
 		return errors.New("no reader provided")
 	}
 
-	pciDevices, err := g.pciReader.Read()
+	pciDevices, numaNodes, switches, err := g.readDevices()
 	if err != nil {
 		return fmt.Errorf("failed to read pci devices: %w", err)
 	}
 
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.numaNodes = numaNodes
+	g.switches = switches
+	g.inaccessible = map[pci.Address]bool{}
 	for _, pciDevice := range pciDevices {
+		if !g.accessible(pciDevice) {
+			g.inaccessible[pciDevice] = true
+			continue
+		}
+
 		g.log.V(2).Info("Found device", "pciAddress", pciDevice)
 		g.devices[pciDevice] = ClaimStatusFree
 	}
 
+	if len(g.devices) == 0 {
+		g.log.Info("No devices discovered, all claims will fail", "plugin", g.name)
+	}
+
+	g.indexed = make([]pci.Address, 0, len(g.devices))
+	for device := range g.devices {
+		g.indexed = append(g.indexed, device)
+	}
+	slices.SortFunc(g.indexed, pci.Address.Compare)
+
 	for _, pciDevice := range g.preClaimed {
 		if _, ok := g.devices[pciDevice]; !ok {
 			g.log.V(2).Info("Not discovered pre-claimed pci address", "pciAddress", pciDevice)
@@ -149,6 +670,100 @@ func (g *gpuClaimPlugin) Init() error {
 	return nil
 }
 
+// accessible reports whether the device passes the access checker. Devices failing the check are excluded.
+func (g *gpuClaimPlugin) accessible(device pci.Address) bool {
+	if g.accessChecker == nil {
+		return true
+	}
+
+	accessible, err := g.accessChecker.Accessible(device)
+	if err != nil {
+		g.log.Error(err, "Failed to check device access, excluding device", "pciAddress", device)
+		return false
+	}
+	if !accessible {
+		g.log.Info("Excluding inaccessible device", "pciAddress", device)
+	}
+	return accessible
+}
+
+// readDevices reads the devices to manage and their known NUMA nodes and upstream bridges. Disabled
+// devices are skipped if the reader reports device details.
+func (g *gpuClaimPlugin) readDevices() ([]pci.Address, map[pci.Address]int, map[pci.Address]pci.Address, error) {
+	numaNodes := map[pci.Address]int{}
+	switches := map[pci.Address]pci.Address{}
+
+	infoReader, ok := g.pciReader.(pci.InfoReader)
+	if !ok {
+		devices, err := g.pciReader.Read()
+		return devices, numaNodes, switches, err
+	}
+
+	infos, err := infoReader.ReadInfo()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var devices []pci.Address
+	for _, info := range infos {
+		if info.Disabled {
+			g.log.V(2).Info("Skipping disabled device", "pciAddress", info.Address, "powerState", info.PowerState)
+			continue
+		}
+		if info.NUMANode != nil {
+			numaNodes[info.Address] = *info.NUMANode
+		}
+		if info.Parent != nil {
+			switches[info.Address] = *info.Parent
+		}
+		devices = append(devices, info.Address)
+	}
+	return devices, numaNodes, switches, nil
+}
+
+func (g *gpuClaimPlugin) Capacity() resource.Quantity {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return *resource.NewQuantity(int64(len(g.devices)), resource.DecimalSI)
+}
+
+func (g *gpuClaimPlugin) Available() resource.Quantity {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return *resource.NewQuantity(g.free(), resource.DecimalSI)
+}
+
+// ListDevices returns the pci addresses of all managed devices, sorted by address.
+func (g *gpuClaimPlugin) ListDevices() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	devices := make([]string, 0, len(g.indexed))
+	for _, device := range g.indexed {
+		devices = append(devices, device.String())
+	}
+	return devices
+}
+
+// IsDeviceFree reports whether the device with the given pci address is not claimed.
+func (g *gpuClaimPlugin) IsDeviceFree(deviceID string) (bool, error) {
+	address, err := pci.ParseAddress(deviceID)
+	if err != nil {
+		return false, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	status, ok := g.devices[address]
+	if !ok {
+		return false, fmt.Errorf("%s: %w", address, ErrDeviceNotManaged)
+	}
+	return status == ClaimStatusFree, nil
+}
+
 func (g *gpuClaimPlugin) Name() string {
 	return g.name
 }
@@ -5,6 +5,9 @@ package gpu_test
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/ironcore-dev/provider-utils/claimutils/claim"
 	"github.com/ironcore-dev/provider-utils/claimutils/gpu"
@@ -24,6 +27,15 @@ func (m *MockReader) Read() ([]pci.Address, error) {
 	return m.devices, m.err
 }
 
+type MockInfoReader struct {
+	MockReader
+	infos []pci.DeviceInfo
+}
+
+func (m *MockInfoReader) ReadInfo() ([]pci.DeviceInfo, error) {
+	return m.infos, m.err
+}
+
 var _ = Describe("GPU Claimer", func() {
 
 	It("should init correct", func(ctx SpecContext) {
@@ -55,7 +67,8 @@ var _ = Describe("GPU Claimer", func() {
 		By("claim resources")
 		_, err := plugin.Claim(resource.MustParse("1"))
 		Expect(err).To(MatchError(claim.ErrInsufficientResources))
-
+		Expect(err).To(MatchError(gpu.ErrNoDevicesDiscovered))
+		Expect(err.Error()).NotTo(ContainSubstring("\n"))
 	})
 
 	It("should error if no resource left", func(ctx SpecContext) {
@@ -70,6 +83,7 @@ var _ = Describe("GPU Claimer", func() {
 		By("claim resources")
 		_, err := plugin.Claim(resource.MustParse("1"))
 		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+		Expect(errors.Is(err, gpu.ErrNoDevicesDiscovered)).To(BeFalse())
 	})
 
 	It("should claim device if enough are present", func(ctx SpecContext) {
@@ -213,4 +227,210 @@ var _ = Describe("GPU Claimer", func() {
 		Expect(plugin.Release(nil)).To(MatchError(claim.ErrInvalidResourceClaim))
 	})
 
+	It("should claim devices by index", func(ctx SpecContext) {
+		By("init plugin")
+		plugin := gpu.NewGPUClaimPlugin(log.FromContext(ctx), "test-plugin", &MockReader{
+			devices: []pci.Address{
+				{Bus: 0x97},
+				{Bus: 0x17},
+				{Bus: 0x3b},
+			},
+		}, nil)
+		Expect(plugin.Init()).ShouldNot(HaveOccurred())
+
+		indexClaimer, ok := plugin.(gpu.IndexClaimer)
+		Expect(ok).To(BeTrue())
+
+		By("claiming indices 0 and 2")
+		resourceClaim, err := indexClaimer.ClaimByIndex([]int{0, 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceClaim.(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{
+			{Bus: 0x17},
+			{Bus: 0x97},
+		}))
+
+		By("claiming the remaining device by quantity")
+		remaining, err := plugin.Claim(resource.MustParse("1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remaining.(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x3b}}))
+	})
+
+	It("should reject invalid device indices", func(ctx SpecContext) {
+		By("init plugin")
+		plugin := gpu.NewGPUClaimPlugin(log.FromContext(ctx), "test-plugin", &MockReader{
+			devices: []pci.Address{
+				{},
+				{Function: 1},
+			},
+		}, []pci.Address{{Function: 1}})
+		Expect(plugin.Init()).ShouldNot(HaveOccurred())
+
+		indexClaimer, ok := plugin.(gpu.IndexClaimer)
+		Expect(ok).To(BeTrue())
+
+		By("claiming out-of-range indices")
+		_, err := indexClaimer.ClaimByIndex([]int{2})
+		Expect(err).To(MatchError(gpu.ErrIndexOutOfRange))
+		_, err = indexClaimer.ClaimByIndex([]int{-1})
+		Expect(err).To(MatchError(gpu.ErrIndexOutOfRange))
+
+		By("claiming an already claimed index")
+		_, err = indexClaimer.ClaimByIndex([]int{1})
+		Expect(err).To(MatchError(gpu.ErrDeviceAlreadyClaimed))
+
+		By("claiming the same index twice")
+		_, err = indexClaimer.ClaimByIndex([]int{0, 0})
+		Expect(err).To(MatchError(gpu.ErrDeviceAlreadyClaimed))
+
+		By("asserting failed claims did not claim anything")
+		_, err = indexClaimer.ClaimByIndex([]int{0})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should claim all available devices", func(ctx SpecContext) {
+		By("init plugin")
+		plugin := gpu.NewGPUClaimPlugin(log.FromContext(ctx), "test-plugin", &MockReader{
+			devices: []pci.Address{
+				{},
+				{Function: 1},
+				{Function: 2},
+			},
+		}, []pci.Address{{Function: 1}})
+		Expect(plugin.Init()).ShouldNot(HaveOccurred())
+
+		By("claiming all available devices")
+		Expect(plugin.CanClaim(gpu.AllAvailable())).To(BeTrue())
+		gpuClaim, err := plugin.Claim(gpu.AllAvailable())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gpuClaim.(gpu.Claim).PCIAddresses()).To(ConsistOf(pci.Address{}, pci.Address{Function: 2}))
+
+		By("asserting nothing is left")
+		Expect(plugin.CanClaim(resource.MustParse("1"))).To(BeFalse())
+		Expect(plugin.CanClaim(gpu.AllAvailable())).To(BeFalse())
+		_, err = plugin.Claim(gpu.AllAvailable())
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+	})
+
+	It("should require the configured minimum for all available claims", func(ctx SpecContext) {
+		By("init plugin")
+		plugin := gpu.NewGPUClaimPluginWithOptions(log.FromContext(ctx), "test-plugin", &MockReader{
+			devices: []pci.Address{
+				{},
+				{Function: 1},
+			},
+		}, []pci.Address{{Function: 1}}, gpu.Options{MinAllAvailable: 2})
+		Expect(plugin.Init()).ShouldNot(HaveOccurred())
+
+		By("claiming all available devices with too few free")
+		Expect(plugin.CanClaim(gpu.AllAvailable())).To(BeFalse())
+		_, err := plugin.Claim(gpu.AllAvailable())
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+	})
+
+	It("should skip disabled devices", func(ctx SpecContext) {
+		By("init plugin")
+		plugin := gpu.NewGPUClaimPlugin(log.FromContext(ctx), "test-plugin", &MockInfoReader{
+			infos: []pci.DeviceInfo{
+				{Address: pci.Address{Bus: 0x17}, PowerState: "D0"},
+				{Address: pci.Address{Bus: 0x3b}, Disabled: true, PowerState: "D3cold"},
+			},
+		}, nil)
+		Expect(plugin.Init()).ShouldNot(HaveOccurred())
+
+		By("claiming the enabled device")
+		gpuClaim, err := plugin.Claim(resource.MustParse("1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gpuClaim.(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x17}}))
+
+		By("failing to claim the disabled device")
+		_, err = plugin.Claim(resource.MustParse("1"))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+	})
+
+	It("should exclude inaccessible devices", func(ctx SpecContext) {
+		By("init plugin with an access checker denying one device")
+		plugin := gpu.NewGPUClaimPluginWithOptions(log.FromContext(ctx), "test-plugin", &MockReader{
+			devices: []pci.Address{
+				{Bus: 0x17},
+				{Bus: 0x3b},
+			},
+		}, nil, gpu.Options{
+			AccessChecker: gpu.AccessCheckerFunc(func(address pci.Address) (bool, error) {
+				return address.Bus != 0x3b, nil
+			}),
+		})
+		Expect(plugin.Init()).ShouldNot(HaveOccurred())
+
+		By("claiming the accessible device")
+		gpuClaim, err := plugin.Claim(resource.MustParse("1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gpuClaim.(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x17}}))
+
+		By("failing to claim the inaccessible device")
+		_, err = plugin.Claim(resource.MustParse("1"))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+	})
+
+	It("should resolve nvidia device nodes to check access", func() {
+		procRoot := GinkgoT().TempDir()
+		devRoot := GinkgoT().TempDir()
+
+		for address, minor := range map[pci.Address]int{{Bus: 0x17}: 0, {Bus: 0x3b}: 1} {
+			dir := filepath.Join(procRoot, "driver", "nvidia", "gpus", address.String())
+			Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+			information := fmt.Sprintf("Model: \t\t NVIDIA H100\nDevice Minor: \t %d\n", minor)
+			Expect(os.WriteFile(filepath.Join(dir, "information"), []byte(information), 0o644)).To(Succeed())
+		}
+		Expect(os.WriteFile(filepath.Join(devRoot, "nvidia0"), nil, 0o666)).To(Succeed())
+
+		checker := gpu.NewNvidiaAccessChecker(procRoot, devRoot)
+		Expect(checker.Accessible(pci.Address{Bus: 0x17})).To(BeTrue())
+		Expect(checker.Accessible(pci.Address{Bus: 0x3b})).To(BeFalse())
+
+		_, err := checker.Accessible(pci.Address{Bus: 0x97})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should flag claimed devices that disappeared", func(ctx SpecContext) {
+		By("init plugin")
+		reader := &MockReader{
+			devices: []pci.Address{
+				{Bus: 0x17},
+				{Bus: 0x3b},
+			},
+		}
+		plugin := gpu.NewGPUClaimPlugin(log.FromContext(ctx), "test-plugin", reader, nil)
+		Expect(plugin.Init()).ShouldNot(HaveOccurred())
+
+		driftChecker, ok := plugin.(gpu.DriftChecker)
+		Expect(ok).To(BeTrue())
+		_, ok = driftChecker.LastDriftReport()
+		Expect(ok).To(BeFalse())
+
+		By("claiming a device")
+		gpuClaim, err := plugin.Claim(resource.MustParse("1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gpuClaim.(gpu.Claim).PCIAddresses()).To(Equal([]pci.Address{{Bus: 0x17}}))
+
+		By("checking without drift")
+		Expect(driftChecker.CheckDrift().HasDiscrepancies()).To(BeFalse())
+
+		By("removing the claimed device and adding an unknown one")
+		reader.devices = []pci.Address{
+			{Bus: 0x3b},
+			{Bus: 0x97},
+		}
+
+		report := driftChecker.CheckDrift()
+		Expect(report.Err).NotTo(HaveOccurred())
+		Expect(report.HasDiscrepancies()).To(BeTrue())
+		Expect(report.MissingClaimed).To(Equal([]pci.Address{{Bus: 0x17}}))
+		Expect(report.Missing).To(BeEmpty())
+		Expect(report.Unmanaged).To(Equal([]pci.Address{{Bus: 0x97}}))
+
+		By("exposing the last report")
+		lastReport, ok := driftChecker.LastDriftReport()
+		Expect(ok).To(BeTrue())
+		Expect(lastReport).To(Equal(report))
+	})
 })
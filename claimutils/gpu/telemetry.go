@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gpu
+
+import (
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+// telemetrySample is the telemetry of a device read at sampledAt, ok is false if the read failed.
+type telemetrySample struct {
+	telemetry pci.Telemetry
+	ok        bool
+	sampledAt time.Time
+}
+
+// deviceLoad returns the telemetry of the devices reporting it. Samples are reused for
+// Options.TelemetryTTL, including failed reads, so a slow or broken source is read at most once per
+// device and TTL.
+func (g *gpuClaimPlugin) deviceLoad(devices []pci.Address) map[pci.Address]pci.Telemetry {
+	load := map[pci.Address]pci.Telemetry{}
+	if g.telemetrySource == nil {
+		return load
+	}
+
+	g.telemetryMu.Lock()
+	defer g.telemetryMu.Unlock()
+
+	now := time.Now()
+	for _, device := range devices {
+		sample, ok := g.telemetry[device]
+		if !ok || now.Sub(sample.sampledAt) >= g.telemetryTTL {
+			sample = g.sampleTelemetry(device, now)
+			g.telemetry[device] = sample
+		}
+		if sample.ok {
+			load[device] = sample.telemetry
+		}
+	}
+	return load
+}
+
+func (g *gpuClaimPlugin) sampleTelemetry(device pci.Address, now time.Time) telemetrySample {
+	telemetry, err := g.telemetrySource.Telemetry(device)
+	if err != nil {
+		g.log.V(2).Info("Failed to read device telemetry", "device", device, "error", err)
+		return telemetrySample{sampledAt: now}
+	}
+	return telemetrySample{telemetry: telemetry, ok: true, sampledAt: now}
+}
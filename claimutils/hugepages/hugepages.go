@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package hugepages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	ErrTotalPathNotConfigured = errors.New("hugepages total path not configured")
+)
+
+type Claim interface {
+	claim.ResourceClaim
+	Pages() int64
+}
+
+func NewHugepagesClaim(pages int64) Claim {
+	return &hugepagesClaim{
+		pages: pages,
+	}
+}
+
+type hugepagesClaim struct {
+	pages int64
+}
+
+func (c hugepagesClaim) Pages() int64 {
+	return c.pages
+}
+
+// Options defines options to initialize the hugepages claim plugin.
+type Options struct {
+	// TotalPath is the file holding the total number of hugepages. Defaults to DefaultTotalPath.
+	TotalPath string
+}
+
+func (o *Options) Defaults() {
+	if o.TotalPath == "" {
+		o.TotalPath = DefaultTotalPath
+	}
+}
+
+// NewHugepagesClaimPlugin returns a plugin claiming hugepages, quantities are numbers of pages.
+func NewHugepagesClaimPlugin(log logr.Logger, name string) claim.Plugin {
+	return NewHugepagesClaimPluginWithOptions(log, name, Options{})
+}
+
+func NewHugepagesClaimPluginWithOptions(log logr.Logger, name string, opts Options) claim.Plugin {
+	opts.Defaults()
+
+	return &hugepagesClaimPlugin{
+		name:      name,
+		log:       log,
+		totalPath: opts.TotalPath,
+	}
+}
+
+type hugepagesClaimPlugin struct {
+	name      string
+	log       logr.Logger
+	totalPath string
+
+	total   int64
+	claimed int64
+}
+
+func (h *hugepagesClaimPlugin) Name() string {
+	return h.name
+}
+
+func (h *hugepagesClaimPlugin) Capacity() resource.Quantity {
+	return *resource.NewQuantity(h.total, resource.DecimalSI)
+}
+
+func (h *hugepagesClaimPlugin) Available() resource.Quantity {
+	return *resource.NewQuantity(max(h.total-h.claimed, 0), resource.DecimalSI)
+}
+
+func (h *hugepagesClaimPlugin) CanClaim(quantity resource.Quantity) bool {
+	requested, err := claim.Int64Value(quantity)
+	if err != nil {
+		h.log.V(2).Info("Requested quantity overflows", "quantity", quantity.String())
+		return false
+	}
+	free := h.total - h.claimed
+	h.log.V(2).Info("Try to claim hugepages", "free", free, "requested", requested)
+
+	return requested >= 0 && free >= requested
+}
+
+func (h *hugepagesClaimPlugin) Claim(quantity resource.Quantity) (claim.ResourceClaim, error) {
+	if !h.CanClaim(quantity) {
+		return nil, fmt.Errorf("requested %s of %d free pages: %w", quantity.String(), max(h.total-h.claimed, 0), claim.ErrInsufficientResources)
+	}
+
+	pages := quantity.Value()
+	claimed, err := claim.AddInt64(h.claimed, pages)
+	if err != nil {
+		return nil, errors.Join(claim.ErrInsufficientResources, err)
+	}
+	h.claimed = claimed
+	h.log.V(2).Info("Claimed hugepages", "pages", pages, "claimed", h.claimed, "total", h.total)
+
+	return &hugepagesClaim{pages: pages}, nil
+}
+
+func (h *hugepagesClaimPlugin) Release(resourceClaim claim.ResourceClaim) error {
+	hugepages, ok := resourceClaim.(Claim)
+	if !ok {
+		return fmt.Errorf("%T is no hugepages claim: %w", resourceClaim, claim.ErrInvalidResourceClaim)
+	}
+
+	h.claimed -= hugepages.Pages()
+	if h.claimed < 0 {
+		h.log.V(1).Info("Released more hugepages than claimed", "pages", hugepages.Pages())
+		h.claimed = 0
+	}
+	h.log.V(3).Info("Released hugepages", "pages", hugepages.Pages(), "claimed", h.claimed)
+
+	return nil
+}
+
+// Init reads the total number of hugepages. Claimed pages are kept, so re-initializing picks up
+// a changed total without losing track of issued claims.
+func (h *hugepagesClaimPlugin) Init() error {
+	if h.totalPath == "" {
+		return ErrTotalPathNotConfigured
+	}
+
+	data, err := os.ReadFile(h.totalPath)
+	if err != nil {
+		return fmt.Errorf("failed to read hugepages total: %w", err)
+	}
+
+	total, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse hugepages total %q: %w", h.totalPath, err)
+	}
+
+	if total < h.claimed {
+		h.log.Info("Hugepages total is lower than the claimed pages", "total", total, "claimed", h.claimed)
+	}
+	h.total = total
+	h.log.V(1).Info("Discovered hugepages", "total", total)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package hugepages_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/claim"
+	"github.com/ironcore-dev/provider-utils/claimutils/claimtest"
+	"github.com/ironcore-dev/provider-utils/claimutils/hugepages"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func writeTotal(dir, total string) (string, error) {
+	path := filepath.Join(dir, "nr_hugepages")
+	return path, os.WriteFile(path, []byte(total+"\n"), 0o644)
+}
+
+func TestHugepagesPluginConformance(t *testing.T) {
+	path, err := writeTotal(t.TempDir(), "2")
+	if err != nil {
+		t.Fatalf("writeTotal: %v", err)
+	}
+
+	claimtest.RunPluginConformance(t, func() claim.Plugin {
+		return hugepages.NewHugepagesClaimPluginWithOptions(log.Log, "hugepages-1Gi", hugepages.Options{
+			TotalPath: path,
+		})
+	})
+}
+
+var _ = Describe("Hugepages Claimer", func() {
+	It("should claim and release pages of the total", func(ctx SpecContext) {
+		path, err := writeTotal(GinkgoT().TempDir(), "4")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("init plugin")
+		plugin := hugepages.NewHugepagesClaimPluginWithOptions(log.FromContext(ctx), "hugepages-1Gi", hugepages.Options{
+			TotalPath: path,
+		})
+		Expect(plugin.Init()).To(Succeed())
+
+		By("claiming pages")
+		pagesClaim, err := plugin.Claim(resource.MustParse("3"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pagesClaim.(hugepages.Claim).Pages()).To(Equal(int64(3)))
+
+		By("failing to over-claim")
+		Expect(plugin.CanClaim(resource.MustParse("2"))).To(BeFalse())
+		_, err = plugin.Claim(resource.MustParse("2"))
+		Expect(err).To(MatchError(claim.ErrInsufficientResources))
+
+		By("claiming the remaining page")
+		remaining, err := plugin.Claim(resource.MustParse("1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.CanClaim(resource.MustParse("1"))).To(BeFalse())
+
+		By("releasing pages")
+		Expect(plugin.Release(pagesClaim)).To(Succeed())
+		Expect(plugin.CanClaim(resource.MustParse("3"))).To(BeTrue())
+		Expect(plugin.CanClaim(resource.MustParse("4"))).To(BeFalse())
+		Expect(plugin.Release(remaining)).To(Succeed())
+		Expect(plugin.CanClaim(resource.MustParse("4"))).To(BeTrue())
+	})
+
+	It("should fail to init without total", func(ctx SpecContext) {
+		plugin := hugepages.NewHugepagesClaimPluginWithOptions(log.FromContext(ctx), "hugepages-1Gi", hugepages.Options{
+			TotalPath: filepath.Join(GinkgoT().TempDir(), "missing"),
+		})
+		Expect(plugin.Init()).To(HaveOccurred())
+
+		path, err := writeTotal(GinkgoT().TempDir(), "many")
+		Expect(err).NotTo(HaveOccurred())
+		plugin = hugepages.NewHugepagesClaimPluginWithOptions(log.FromContext(ctx), "hugepages-1Gi", hugepages.Options{
+			TotalPath: path,
+		})
+		Expect(plugin.Init()).To(HaveOccurred())
+	})
+})
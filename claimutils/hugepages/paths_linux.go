@@ -0,0 +1,7 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package hugepages
+
+// DefaultTotalPath is the sysfs file holding the number of 1GiB hugepages.
+const DefaultTotalPath = "/sys/kernel/mm/hugepages/hugepages-1048576kB/nr_hugepages"
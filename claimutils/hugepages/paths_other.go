@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package hugepages
+
+// DefaultTotalPath is empty as hugepages are only discovered on linux, Options.TotalPath has to be set.
+const DefaultTotalPath = ""
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+func TestAddress_Compare(t *testing.T) {
+	want := []pci.Address{
+		{Domain: 0, Bus: 0x17, Slot: 0, Function: 0},
+		{Domain: 0, Bus: 0x17, Slot: 0, Function: 1},
+		{Domain: 0, Bus: 0x17, Slot: 1, Function: 0},
+		{Domain: 0, Bus: 0x97, Slot: 0, Function: 0},
+		{Domain: 1, Bus: 0x00, Slot: 0, Function: 0},
+	}
+
+	got := slices.Clone(want)
+	rand.Shuffle(len(got), func(i, j int) { got[i], got[j] = got[j], got[i] })
+	slices.SortFunc(got, pci.Address.Compare)
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected order:\n got: %v\nwant: %v", got, want)
+	}
+
+	for i := range len(want) - 1 {
+		if !want[i].Less(want[i+1]) {
+			t.Fatalf("expected %s to be less than %s", want[i], want[i+1])
+		}
+		if want[i+1].Less(want[i]) {
+			t.Fatalf("expected %s not to be less than %s", want[i+1], want[i])
+		}
+	}
+	if want[0].Compare(want[0]) != 0 {
+		t.Fatalf("expected %s to compare equal to itself", want[0])
+	}
+}
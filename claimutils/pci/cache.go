@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
+)
+
+// CachingReaderOptions defines options to initialize the caching reader.
+type CachingReaderOptions struct {
+	// TTL is the duration a read is cached for. Defaults to 30s.
+	TTL time.Duration
+	// Clock defaults to the real clock.
+	Clock clock.PassiveClock
+	// DebounceWindow, if set, makes Start refresh the cache once per burst of hotplug events, after no
+	// further event arrived for the window. A single hotplug can cause a burst of events while the
+	// kernel populates the attributes of the device.
+	DebounceWindow time.Duration
+}
+
+func (o *CachingReaderOptions) Defaults() {
+	if o.TTL <= 0 {
+		o.TTL = 30 * time.Second
+	}
+
+	if o.Clock == nil {
+		o.Clock = clock.RealClock{}
+	}
+}
+
+// CachingReader caches the devices read by an inner reader for a TTL. If the inner reader is a
+// HotplugWatcher, Start refreshes the cache on every hotplug event.
+type CachingReader struct {
+	log   logr.Logger
+	inner Reader
+	ttl   time.Duration
+	clock clock.PassiveClock
+
+	debounceWindow time.Duration
+
+	mu        sync.Mutex
+	devices   []Address
+	fetchedAt time.Time
+	valid     bool
+}
+
+func NewCachingReader(log logr.Logger, inner Reader, opts CachingReaderOptions) *CachingReader {
+	opts.Defaults()
+
+	return &CachingReader{
+		log:   log,
+		inner: inner,
+		ttl:   opts.TTL,
+		clock: opts.Clock,
+
+		debounceWindow: opts.DebounceWindow,
+	}
+}
+
+func (r *CachingReader) Read() ([]Address, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.valid && r.clock.Since(r.fetchedAt) < r.ttl {
+		return slices.Clone(r.devices), nil
+	}
+
+	if err := r.refresh(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(r.devices), nil
+}
+
+// Invalidate drops the cached devices, the next Read reads from the inner reader.
+func (r *CachingReader) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.valid = false
+}
+
+// Start refreshes the cache on hotplug events of the inner reader until the context is done.
+// If the inner reader does not support hotplug events, the cache is only refreshed after the TTL.
+func (r *CachingReader) Start(ctx context.Context) error {
+	watcher, ok := r.inner.(HotplugWatcher)
+	if !ok {
+		r.log.V(1).Info("Inner reader does not support hotplug events")
+		<-ctx.Done()
+		return nil
+	}
+
+	events, err := watcher.WatchHotplug(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch hotplug events: %w", err)
+	}
+
+	if r.debounceWindow <= 0 {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case event, ok := <-events:
+				if !ok {
+					return r.watchClosed(ctx)
+				}
+
+				r.log.V(1).Info("Refreshing cache on hotplug event", "type", event.Type, "pciAddress", event.Address)
+				r.refreshOrInvalidate()
+			}
+		}
+	}
+
+	clk, ok := r.clock.(clock.Clock)
+	if !ok {
+		clk = clock.RealClock{}
+	}
+	for burst := range Debounce(ctx, clk, r.debounceWindow, events) {
+		r.log.V(1).Info("Refreshing cache on hotplug events", "events", len(burst))
+		r.refreshOrInvalidate()
+	}
+	return r.watchClosed(ctx)
+}
+
+func (r *CachingReader) watchClosed(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("hotplug watch closed")
+}
+
+func (r *CachingReader) refreshOrInvalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.refresh(); err != nil {
+		r.log.Error(err, "failed to refresh cache, invalidating it")
+		r.valid = false
+	}
+}
+
+func (r *CachingReader) refresh() error {
+	devices, err := r.inner.Read()
+	if err != nil {
+		return err
+	}
+
+	r.devices = devices
+	r.fetchedAt = r.clock.Now()
+	r.valid = true
+	return nil
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	testingclock "k8s.io/utils/clock/testing"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeHotplugReader struct {
+	mu      sync.Mutex
+	devices []pci.Address
+	reads   int
+	events  chan pci.HotplugEvent
+}
+
+func (f *fakeHotplugReader) Read() ([]pci.Address, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reads++
+	return slices.Clone(f.devices), nil
+}
+
+func (f *fakeHotplugReader) WatchHotplug(_ context.Context) (<-chan pci.HotplugEvent, error) {
+	return f.events, nil
+}
+
+func (f *fakeHotplugReader) setDevices(devices []pci.Address) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.devices = devices
+}
+
+func (f *fakeHotplugReader) readCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.reads
+}
+
+func TestCachingReader_TTL(t *testing.T) {
+	fakeClock := testingclock.NewFakePassiveClock(time.Now())
+	inner := &fakeHotplugReader{devices: []pci.Address{{Bus: 0x17}}}
+	reader := pci.NewCachingReader(log.Log, inner, pci.CachingReaderOptions{
+		TTL:   time.Minute,
+		Clock: fakeClock,
+	})
+
+	for range 3 {
+		if _, err := reader.Read(); err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+	}
+	if got := inner.readCount(); got != 1 {
+		t.Fatalf("expected 1 inner read within ttl, got %d", got)
+	}
+
+	fakeClock.SetTime(fakeClock.Now().Add(2 * time.Minute))
+	if _, err := reader.Read(); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got := inner.readCount(); got != 2 {
+		t.Fatalf("expected 2 inner reads after ttl, got %d", got)
+	}
+}
+
+func TestCachingReader_Hotplug(t *testing.T) {
+	inner := &fakeHotplugReader{
+		devices: []pci.Address{{Bus: 0x17}},
+		events:  make(chan pci.HotplugEvent),
+	}
+	reader := pci.NewCachingReader(log.Log, inner, pci.CachingReaderOptions{TTL: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- reader.Start(ctx) }()
+
+	devices, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if want := []pci.Address{{Bus: 0x17}}; !slices.Equal(devices, want) {
+		t.Fatalf("expected %v, got %v", want, devices)
+	}
+
+	inner.setDevices([]pci.Address{{Bus: 0x17}, {Bus: 0x3b}})
+	inner.events <- pci.HotplugEvent{Type: pci.HotplugEventAdd, Address: pci.Address{Bus: 0x3b}}
+
+	waitForDevices(t, reader, []pci.Address{{Bus: 0x17}, {Bus: 0x3b}})
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import (
+	"slices"
+	"sync"
+)
+
+// ChangeDetectingReader remembers the devices of the last scan to report whether they changed.
+type ChangeDetectingReader struct {
+	inner Reader
+
+	mu       sync.Mutex
+	previous []Address
+	scanned  bool
+}
+
+func NewChangeDetectingReader(inner Reader) *ChangeDetectingReader {
+	return &ChangeDetectingReader{
+		inner: inner,
+	}
+}
+
+func (r *ChangeDetectingReader) Read() ([]Address, error) {
+	return r.inner.Read()
+}
+
+// ReadIfChanged reads the devices and reports whether they differ from the ones of the previous
+// ReadIfChanged, ignoring their order. The first scan always counts as changed, a failed scan
+// returns the error and keeps the previous devices.
+func (r *ChangeDetectingReader) ReadIfChanged() ([]Address, bool, error) {
+	devices, err := r.inner.Read()
+	if err != nil {
+		return nil, false, err
+	}
+
+	sorted := slices.Clone(devices)
+	slices.SortFunc(sorted, Address.Compare)
+	sorted = slices.Compact(sorted)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := !r.scanned || !slices.Equal(r.previous, sorted)
+	r.previous, r.scanned = sorted, true
+	return devices, changed, nil
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci_test
+
+import (
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+func TestChangeDetectingReader_ReadIfChanged(t *testing.T) {
+	inner := &fakeHotplugReader{devices: []pci.Address{{Bus: 0x17}, {Bus: 0x97}}}
+	reader := pci.NewChangeDetectingReader(inner)
+
+	readIfChanged := func(expected bool) {
+		t.Helper()
+		if _, changed, err := reader.ReadIfChanged(); err != nil {
+			t.Fatalf("ReadIfChanged: %v", err)
+		} else if changed != expected {
+			t.Fatalf("expected changed=%v, got %v", expected, changed)
+		}
+	}
+
+	readIfChanged(true)
+	readIfChanged(false)
+
+	inner.setDevices([]pci.Address{{Bus: 0x97}, {Bus: 0x17}})
+	readIfChanged(false)
+
+	inner.setDevices([]pci.Address{{Bus: 0x17}})
+	readIfChanged(true)
+	readIfChanged(false)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// Debounce collects bursts of events, e.g. the hotplug events of the kernel populating the sysfs
+// attributes of a new device. A burst is sent to the returned channel once no further event arrived
+// for the window. The channel is closed after the last burst once events is closed, or once the
+// context is done.
+func Debounce[T any](ctx context.Context, clk clock.Clock, window time.Duration, events <-chan T) <-chan []T {
+	bursts := make(chan []T)
+	go func() {
+		defer close(bursts)
+
+		var (
+			burst []T
+			timer clock.Timer
+			fire  <-chan time.Time
+		)
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		send := func() bool {
+			select {
+			case bursts <- burst:
+				burst, fire = nil, nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					if len(burst) > 0 {
+						send()
+					}
+					return
+				}
+
+				burst = append(burst, event)
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = clk.NewTimer(window)
+				fire = timer.C()
+			case <-fire:
+				if !send() {
+					return
+				}
+			}
+		}
+	}()
+	return bursts
+}
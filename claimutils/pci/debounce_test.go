@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	"k8s.io/utils/clock"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestDebounce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan int)
+	bursts := pci.Debounce(ctx, clock.RealClock{}, 100*time.Millisecond, events)
+
+	for i := range 10 {
+		events <- i
+	}
+
+	select {
+	case burst := <-bursts:
+		if len(burst) != 10 {
+			t.Fatalf("expected a single burst of 10 events, got %v", burst)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a burst after the window")
+	}
+
+	close(events)
+	if burst, ok := <-bursts; ok {
+		t.Fatalf("expected no further burst, got %v", burst)
+	}
+}
+
+func TestCachingReader_DebouncedHotplug(t *testing.T) {
+	inner := &fakeHotplugReader{
+		devices: []pci.Address{{Bus: 0x17}},
+		events:  make(chan pci.HotplugEvent),
+	}
+	reader := pci.NewCachingReader(log.Log, inner, pci.CachingReaderOptions{
+		TTL:            time.Hour,
+		DebounceWindow: 100 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- reader.Start(ctx) }()
+
+	if _, err := reader.Read(); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	inner.setDevices([]pci.Address{{Bus: 0x17}, {Bus: 0x3b}})
+	for range 5 {
+		inner.events <- pci.HotplugEvent{Type: pci.HotplugEventAdd, Address: pci.Address{Bus: 0x3b}}
+	}
+
+	waitForDevices(t, reader, []pci.Address{{Bus: 0x17}, {Bus: 0x3b}})
+	time.Sleep(300 * time.Millisecond)
+	if got := inner.readCount(); got != 2 {
+		t.Fatalf("expected 1 refresh for the burst of events, got %d inner reads", got)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+}
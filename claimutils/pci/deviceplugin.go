@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+)
+
+var (
+	ErrStreamClosed = errors.New("device plugin stream closed")
+)
+
+// DevicePluginReader reads the devices advertised by a kubelet device plugin via its ListAndWatch stream.
+// Only healthy devices whose id is a pci address are returned.
+type DevicePluginReader struct {
+	log         logr.Logger
+	socket      string
+	readTimeout time.Duration
+
+	mu      sync.RWMutex
+	devices []Address
+	synced  bool
+}
+
+// DevicePluginReaderOptions configures a DevicePluginReader.
+type DevicePluginReaderOptions struct {
+	// ReadTimeout bounds the wait of Read for the first list of a new stream. Defaults to 5 seconds.
+	ReadTimeout time.Duration
+}
+
+func (o *DevicePluginReaderOptions) Defaults() {
+	if o.ReadTimeout <= 0 {
+		o.ReadTimeout = 5 * time.Second
+	}
+}
+
+// NewDevicePluginReader returns a reader for the device plugin serving at the given unix socket.
+func NewDevicePluginReader(log logr.Logger, socket string) *DevicePluginReader {
+	return NewDevicePluginReaderWithOptions(log, socket, DevicePluginReaderOptions{})
+}
+
+// NewDevicePluginReaderWithOptions returns a reader for the device plugin serving at the given unix
+// socket, configured by opts.
+func NewDevicePluginReaderWithOptions(log logr.Logger, socket string, opts DevicePluginReaderOptions) *DevicePluginReader {
+	opts.Defaults()
+
+	return &DevicePluginReader{
+		log:         log,
+		socket:      socket,
+		readTimeout: opts.ReadTimeout,
+	}
+}
+
+// Start watches the device plugin and keeps the devices returned by Read up to date until the
+// context is done or the stream fails.
+func (r *DevicePluginReader) Start(ctx context.Context) error {
+	conn, stream, err := r.listAndWatch(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to receive devices: %w", err)
+		}
+
+		devices := r.addresses(resp.Devices)
+		r.log.V(1).Info("Received devices", "count", len(devices))
+
+		r.mu.Lock()
+		r.devices = devices
+		r.synced = true
+		r.mu.Unlock()
+	}
+}
+
+// Read returns the devices of the last list received by Start. If Start has not received a
+// list yet, the first list of a new stream is returned, waiting at most
+// DevicePluginReaderOptions.ReadTimeout for it.
+func (r *DevicePluginReader) Read() ([]Address, error) {
+	r.mu.RLock()
+	if r.synced {
+		defer r.mu.RUnlock()
+		return slices.Clone(r.devices), nil
+	}
+	r.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.readTimeout)
+	defer cancel()
+
+	conn, stream, err := r.listAndWatch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, errors.Join(ErrStreamClosed, err)
+	}
+
+	return r.addresses(resp.Devices), nil
+}
+
+func (r *DevicePluginReader) listAndWatch(
+	ctx context.Context,
+) (*grpc.ClientConn, pluginapi.DevicePlugin_ListAndWatchClient, error) {
+	conn, err := grpc.NewClient("unix://"+r.socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to device plugin %s: %w", r.socket, err)
+	}
+
+	stream, err := pluginapi.NewDevicePluginClient(conn).ListAndWatch(ctx, &pluginapi.Empty{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("failed to list and watch devices: %w", err)
+	}
+
+	return conn, stream, nil
+}
+
+func (r *DevicePluginReader) addresses(devices []*pluginapi.Device) []Address {
+	var addresses []Address
+	for _, device := range devices {
+		if device.Health != pluginapi.Healthy {
+			continue
+		}
+
+		address, err := ParseAddress(device.ID)
+		if err != nil {
+			r.log.V(2).Info("Ignoring device without pci address", "id", device.ID)
+			continue
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	slices.SortFunc(addresses, Address.Compare)
+	return addresses
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+	"google.golang.org/grpc"
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// fakeDevicePlugin sends its current device list on every new stream and whenever it changes.
+type fakeDevicePlugin struct {
+	pluginapi.UnimplementedDevicePluginServer
+
+	mu      sync.Mutex
+	devices []*pluginapi.Device
+	version int
+}
+
+func (f *fakeDevicePlugin) setDevices(devices []*pluginapi.Device) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.devices = devices
+	f.version++
+}
+
+func (f *fakeDevicePlugin) ListAndWatch(_ *pluginapi.Empty, stream pluginapi.DevicePlugin_ListAndWatchServer) error {
+	sent := 0
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		f.mu.Lock()
+		devices, version := f.devices, f.version
+		f.mu.Unlock()
+
+		if version != sent {
+			if err := stream.Send(&pluginapi.ListAndWatchResponse{Devices: devices}); err != nil {
+				return err
+			}
+			sent = version
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func startFakeDevicePlugin(t *testing.T) (string, *fakeDevicePlugin) {
+	t.Helper()
+
+	// unix socket paths are length limited, t.TempDir may exceed it
+	dir, err := os.MkdirTemp("", "dp")
+	if err != nil {
+		t.Fatalf("MkdirTemp: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "plugin.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+
+	plugin := &fakeDevicePlugin{}
+	server := grpc.NewServer()
+	pluginapi.RegisterDevicePluginServer(server, plugin)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return socket, plugin
+}
+
+func TestDevicePluginReader_Read(t *testing.T) {
+	socket, plugin := startFakeDevicePlugin(t)
+	plugin.setDevices([]*pluginapi.Device{
+		{ID: "0000:3b:00.0", Health: pluginapi.Healthy},
+		{ID: "0000:17:00.0", Health: pluginapi.Healthy},
+		{ID: "0000:5e:00.0", Health: pluginapi.Unhealthy},
+		{ID: "GPU-8f6b6e2c", Health: pluginapi.Healthy},
+	})
+
+	reader := pci.NewDevicePluginReader(log.Log, socket)
+	devices, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	want := []pci.Address{
+		{Domain: 0, Bus: 0x17, Slot: 0, Function: 0},
+		{Domain: 0, Bus: 0x3b, Slot: 0, Function: 0},
+	}
+	if !slices.Equal(devices, want) {
+		t.Fatalf("expected %v, got %v", want, devices)
+	}
+}
+
+func TestDevicePluginReader_ReadTimeout(t *testing.T) {
+	// the plugin never sends a list without devices set
+	socket, _ := startFakeDevicePlugin(t)
+
+	reader := pci.NewDevicePluginReaderWithOptions(log.Log, socket, pci.DevicePluginReaderOptions{
+		ReadTimeout: 50 * time.Millisecond,
+	})
+	start := time.Now()
+	if _, err := reader.Read(); err == nil {
+		t.Fatal("expected Read to fail without a list")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected Read to time out, took %s", elapsed)
+	}
+}
+
+func TestDevicePluginReader_Start(t *testing.T) {
+	socket, plugin := startFakeDevicePlugin(t)
+	reader := pci.NewDevicePluginReader(log.Log, socket)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- reader.Start(ctx) }()
+
+	plugin.setDevices([]*pluginapi.Device{
+		{ID: "0000:17:00.0", Health: pluginapi.Healthy},
+	})
+	waitForDevices(t, reader, []pci.Address{{Bus: 0x17}})
+
+	plugin.setDevices([]*pluginapi.Device{
+		{ID: "0000:17:00.0", Health: pluginapi.Unhealthy},
+		{ID: "0000:3b:00.0", Health: pluginapi.Healthy},
+	})
+	waitForDevices(t, reader, []pci.Address{{Bus: 0x3b}})
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+}
+
+func waitForDevices(t *testing.T, reader pci.Reader, want []pci.Address) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		devices, err := reader.Read()
+		if err == nil && slices.Equal(devices, want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected devices %v, got %v (err: %v)", want, devices, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
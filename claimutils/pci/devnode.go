@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrNoIOMMUGroup = errors.New("device has no iommu group")
+	ErrNoRenderNode = errors.New("device has no render node")
+)
+
+// VFIOGroupPath returns the /dev/vfio/<group> path of the iommu group of the device.
+func (p Address) VFIOGroupPath() (string, error) {
+	return p.VFIOGroupPathWithMount(DefaultMountPoint)
+}
+
+// VFIOGroupPathWithMount is like VFIOGroupPath, reading sysfs from the given mount point.
+func (p Address) VFIOGroupPathWithMount(mountPoint string) (string, error) {
+	target, err := os.Readlink(filepath.Join(p.sysfsPath(mountPoint), "iommu_group"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrNoIOMMUGroup, p)
+		}
+		return "", fmt.Errorf("failed to read iommu group of %s: %w", p, err)
+	}
+
+	return filepath.Join("/dev", "vfio", filepath.Base(target)), nil
+}
+
+// RenderNodePath returns the /dev/dri/renderD* path of the drm render node of the device.
+func (p Address) RenderNodePath() (string, error) {
+	return p.RenderNodePathWithMount(DefaultMountPoint)
+}
+
+// RenderNodePathWithMount is like RenderNodePath, reading sysfs from the given mount point.
+func (p Address) RenderNodePathWithMount(mountPoint string) (string, error) {
+	entries, err := os.ReadDir(filepath.Join(p.sysfsPath(mountPoint), "drm"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrNoRenderNode, p)
+		}
+		return "", fmt.Errorf("failed to read drm devices of %s: %w", p, err)
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "renderD") {
+			return filepath.Join("/dev", "dri", entry.Name()), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrNoRenderNode, p)
+}
+
+func (p Address) sysfsPath(mountPoint string) string {
+	return filepath.Join(mountPoint, "bus", "pci", "devices", p.String())
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package pci_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+func writeFakeDeviceNodes(t *testing.T, sysRoot, id, iommuGroup, renderNode string) {
+	t.Helper()
+
+	devDir := filepath.Join(sysRoot, "devices", "pci0000:00", id)
+
+	groupDir := filepath.Join(sysRoot, "kernel", "iommu_groups", iommuGroup)
+	if err := os.MkdirAll(groupDir, 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", groupDir, err)
+	}
+	groupTarget := filepath.Join("..", "..", "..", "kernel", "iommu_groups", iommuGroup)
+	if err := os.Symlink(groupTarget, filepath.Join(devDir, "iommu_group")); err != nil {
+		t.Fatalf("symlink iommu_group: %v", err)
+	}
+
+	drmDir := filepath.Join(devDir, "drm")
+	for _, name := range []string{"card1", renderNode} {
+		if err := os.MkdirAll(filepath.Join(drmDir, name), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", name, err)
+		}
+	}
+}
+
+func TestAddress_DeviceNodes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	vals := map[string]string{
+		"class":            "0x030200",
+		"vendor":           "0x10de",
+		"device":           "0x2901",
+		"subsystem_vendor": "0x10de",
+		"subsystem_device": "0x0001",
+		"revision":         "0x1",
+	}
+	writeFakePCIDevice(t, tmpDir, "0000:17:00.0", vals)
+	writeFakePCIDevice(t, tmpDir, "0000:97:00.0", vals)
+	writeFakeDeviceNodes(t, tmpDir, "0000:17:00.0", "42", "renderD129")
+
+	addr := pci.Address{Bus: 0x17}
+
+	groupPath, err := addr.VFIOGroupPathWithMount(tmpDir)
+	if err != nil {
+		t.Fatalf("VFIOGroupPath: %v", err)
+	}
+	if groupPath != "/dev/vfio/42" {
+		t.Fatalf("expected /dev/vfio/42, got %s", groupPath)
+	}
+
+	renderNode, err := addr.RenderNodePathWithMount(tmpDir)
+	if err != nil {
+		t.Fatalf("RenderNodePath: %v", err)
+	}
+	if renderNode != "/dev/dri/renderD129" {
+		t.Fatalf("expected /dev/dri/renderD129, got %s", renderNode)
+	}
+
+	withoutNodes := pci.Address{Bus: 0x97}
+	if _, err := withoutNodes.VFIOGroupPathWithMount(tmpDir); !errors.Is(err, pci.ErrNoIOMMUGroup) {
+		t.Fatalf("expected %v, got %v", pci.ErrNoIOMMUGroup, err)
+	}
+	if _, err := withoutNodes.RenderNodePathWithMount(tmpDir); !errors.Is(err, pci.ErrNoRenderNode) {
+		t.Fatalf("expected %v, got %v", pci.ErrNoRenderNode, err)
+	}
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import "context"
+
+type HotplugEventType string
+
+const (
+	HotplugEventAdd    HotplugEventType = "Add"
+	HotplugEventRemove HotplugEventType = "Remove"
+)
+
+// HotplugEvent reports a pci device being added to or removed from the bus.
+type HotplugEvent struct {
+	Type    HotplugEventType
+	Address Address
+}
+
+// HotplugWatcher is implemented by readers that can report hotplug events.
+// The returned channel is closed once the context is done or the watch fails.
+type HotplugWatcher interface {
+	WatchHotplug(ctx context.Context) (<-chan HotplugEvent, error)
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import (
+	"fmt"
+	"slices"
+)
+
+// OverlayReader unions the devices of a live reader with the ones of an overlay reader, e.g. a
+// ReplayReader simulating additional devices. Devices reported by both are taken from the live reader.
+type OverlayReader struct {
+	live    Reader
+	overlay Reader
+}
+
+func NewOverlayReader(live, overlay Reader) *OverlayReader {
+	return &OverlayReader{
+		live:    live,
+		overlay: overlay,
+	}
+}
+
+func (r *OverlayReader) Read() ([]Address, error) {
+	infos, err := r.ReadInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]Address, 0, len(infos))
+	for _, info := range infos {
+		addresses = append(addresses, info.Address)
+	}
+	return addresses, nil
+}
+
+// ReadInfo returns the details of the devices of both readers, sorted by address. Devices of a
+// reader not implementing InfoReader are reported as enabled on an unknown NUMA node without further details.
+func (r *OverlayReader) ReadInfo() ([]DeviceInfo, error) {
+	liveInfos, err := readInfo(r.live)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read live devices: %w", err)
+	}
+
+	overlayInfos, err := readInfo(r.overlay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay devices: %w", err)
+	}
+
+	infos := slices.Clone(liveInfos)
+	for _, info := range overlayInfos {
+		if slices.ContainsFunc(liveInfos, func(live DeviceInfo) bool { return live.Address == info.Address }) {
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	slices.SortFunc(infos, func(a, b DeviceInfo) int {
+		return a.Address.Compare(b.Address)
+	})
+	return infos, nil
+}
+
+func readInfo(reader Reader) ([]DeviceInfo, error) {
+	if infoReader, ok := reader.(InfoReader); ok {
+		return infoReader.ReadInfo()
+	}
+
+	addresses, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]DeviceInfo, 0, len(addresses))
+	for _, address := range addresses {
+		infos = append(infos, DeviceInfo{Address: address})
+	}
+	return infos, nil
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+type fakeInfoReader struct {
+	infos []pci.DeviceInfo
+}
+
+func (f *fakeInfoReader) Read() ([]pci.Address, error) {
+	var addresses []pci.Address
+	for _, info := range f.infos {
+		addresses = append(addresses, info.Address)
+	}
+	return addresses, nil
+}
+
+func (f *fakeInfoReader) ReadInfo() ([]pci.DeviceInfo, error) {
+	return f.infos, nil
+}
+
+func TestOverlayReader_ReadInfo(t *testing.T) {
+	liveDevice := pci.DeviceInfo{
+		Address:    pci.Address{Bus: 0x17},
+		SlotLabel:  "GPU0",
+		PowerState: "D0",
+	}
+	live := &fakeInfoReader{infos: []pci.DeviceInfo{liveDevice}}
+
+	path := filepath.Join(t.TempDir(), "simulated.json")
+	if _, err := pci.NewRecordingReader(&fakeHotplugReader{devices: []pci.Address{
+		{Bus: 0x17},
+		{Bus: 0x97},
+		{Domain: 1, Bus: 0x17},
+	}}, path).Read(); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	reader := pci.NewOverlayReader(live, pci.NewReplayReader(path))
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 devices, got %d: %+v", len(infos), infos)
+	}
+	if infos[0].SlotLabel != liveDevice.SlotLabel || infos[0].PowerState != liveDevice.PowerState {
+		t.Fatalf("expected live device to win, got %+v", infos[0])
+	}
+
+	addresses, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	expected := []pci.Address{{Bus: 0x17}, {Bus: 0x97}, {Domain: 1, Bus: 0x17}}
+	if !slices.Equal(addresses, expected) {
+		t.Fatalf("expected %v, got %v", expected, addresses)
+	}
+}
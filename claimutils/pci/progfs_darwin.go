@@ -4,6 +4,9 @@
 package pci
 
 import (
+	"context"
+	"time"
+
 	"github.com/go-logr/logr"
 )
 
@@ -12,6 +15,10 @@ type reader struct {
 }
 
 func NewReader(log logr.Logger, _ Vendor, _ Class) (*reader, error) {
+	return NewReaderWithOptions(log, ReaderOptions{})
+}
+
+func NewReaderWithOptions(log logr.Logger, _ ReaderOptions) (*reader, error) {
 	log.V(1).Info("NOT SUPPORTED OS")
 
 	return &reader{
@@ -24,3 +31,22 @@ func (r *reader) Read() ([]Address, error) {
 	r.log.V(1).Info("NOT SUPPORTED OS")
 	return nil, nil
 }
+
+func (r *reader) ReadContext(_ context.Context) ([]Address, error) {
+	return r.Read()
+}
+
+func (r *reader) ReadInfo() ([]DeviceInfo, error) {
+	r.log.V(1).Info("NOT SUPPORTED OS")
+	return nil, nil
+}
+
+func (r *reader) ReadWithReport() ([]Address, ScanReport, error) {
+	r.log.V(1).Info("NOT SUPPORTED OS")
+	return nil, ScanReport{}, nil
+}
+
+func (r *reader) ReadTimed() ([]Address, time.Duration, error) {
+	addresses, err := r.Read()
+	return addresses, 0, err
+}
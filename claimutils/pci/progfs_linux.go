@@ -4,84 +4,393 @@
 package pci
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/procfs/sysfs"
 )
 
 type reader struct {
-	log logr.Logger
-	fs  sysfs.FS
+	log        logr.Logger
+	fs         sysfs.FS
+	mountPoint string
 
-	vendorFilter Vendor
-	classFilter  Class
+	vendorFilters []Vendor
+	classFilters  []Class
+	slotLabels    []string
+
+	excludedRevisions []uint8
+	extraAttributes   []string
+	capabilities      []Capability
+
+	// unavailable is set if sysfs could not be opened and EmptyIfUnavailable was set.
+	unavailable bool
 }
 
 func NewReader(log logr.Logger, vendorFilter Vendor, classFilter Class) (*reader, error) {
-	fs, err := sysfs.NewDefaultFS()
+	return NewReaderWithOptions(log, ReaderOptions{
+		Vendor: vendorFilter,
+		Class:  classFilter,
+	})
+}
+
+func NewReaderWithMount(log logr.Logger, mountPoint string, vendorFilter Vendor, classFilter Class) (*reader, error) {
+	return NewReaderWithOptions(log, ReaderOptions{
+		MountPoint: mountPoint,
+		Vendor:     vendorFilter,
+		Class:      classFilter,
+	})
+}
+
+func NewReaderWithOptions(log logr.Logger, opts ReaderOptions) (*reader, error) {
+	opts.Defaults()
+
+	fs, err := sysfs.NewFS(opts.MountPoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open sysfs: %w", err)
+		if !opts.EmptyIfUnavailable {
+			return nil, fmt.Errorf("failed to open sysfs: %w", err)
+		}
+
+		log.Info("Sysfs unavailable, reporting no pci devices", "mountPoint", opts.MountPoint, "error", err)
+		return &reader{log: log, mountPoint: opts.MountPoint, unavailable: true}, nil
+	}
+
+	vendorFilters := slices.Clone(opts.Vendors)
+	if opts.Vendor != 0 {
+		vendorFilters = append([]Vendor{opts.Vendor}, vendorFilters...)
+	}
+	classFilters := slices.Clone(opts.Classes)
+	if opts.Class != 0 {
+		classFilters = append([]Class{opts.Class}, classFilters...)
 	}
 
 	return &reader{
-		log:          log,
-		fs:           fs,
-		vendorFilter: vendorFilter,
-		classFilter:  classFilter,
+		log:           log,
+		fs:            fs,
+		mountPoint:    opts.MountPoint,
+		vendorFilters: vendorFilters,
+		classFilters:  classFilters,
+		slotLabels:    opts.SlotLabels,
+
+		excludedRevisions: opts.ExcludedRevisions,
+		extraAttributes:   opts.ExtraAttributes,
+		capabilities:      opts.Capabilities,
 	}, nil
+}
 
+func (r *reader) Read() ([]Address, error) {
+	return r.read(r.log)
 }
 
-func NewReaderWithMount(log logr.Logger, mountPoint string, vendorFilter Vendor, classFilter Class) (*reader, error) {
-	fs, err := sysfs.NewFS(mountPoint)
+// ReadContext reads like Read, logging via the logger of ctx if it carries one.
+func (r *reader) ReadContext(ctx context.Context) ([]Address, error) {
+	log, err := logr.FromContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open sysfs: %w", err)
+		log = r.log
 	}
+	return r.read(log)
+}
 
-	return &reader{
-		log:          log,
-		fs:           fs,
-		vendorFilter: vendorFilter,
-		classFilter:  classFilter,
-	}, nil
+func (r *reader) read(log logr.Logger) ([]Address, error) {
+	infos, _, err := r.readInfo(log)
+	if err != nil {
+		return nil, err
+	}
 
+	var pciDevices []Address
+	for _, info := range infos {
+		pciDevices = append(pciDevices, info.Address)
+	}
+
+	return pciDevices, nil
 }
 
-func (r *reader) Read() ([]Address, error) {
-	devices, err := r.fs.PciDevices()
+func (r *reader) ReadInfo() ([]DeviceInfo, error) {
+	infos, _, err := r.readInfo(r.log)
+	return infos, err
+}
+
+func (r *reader) ReadWithReport() ([]Address, ScanReport, error) {
+	infos, report, err := r.readInfo(r.log)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read pci devices: %w", err)
+		return nil, ScanReport{}, err
 	}
 
 	var pciDevices []Address
+	for _, info := range infos {
+		pciDevices = append(pciDevices, info.Address)
+	}
+
+	return pciDevices, report, nil
+}
+
+func (r *reader) readInfo(log logr.Logger) ([]DeviceInfo, ScanReport, error) {
+	if r.unavailable {
+		return nil, ScanReport{Skipped: map[SkipReason]int{}}, nil
+	}
+
+	devices, err := r.fs.PciDevices()
+	if err != nil {
+		return nil, ScanReport{}, fmt.Errorf("failed to read pci devices: %w", err)
+	}
+
+	slots, err := r.readSlots(log)
+	if err != nil {
+		return nil, ScanReport{}, err
+	}
+
+	report := ScanReport{
+		Scanned: len(devices),
+		Skipped: map[SkipReason]int{},
+	}
+	var infos []DeviceInfo
 	for _, device := range devices {
+		slotLabel := r.slotLabel(device, slots)
+		id := fmt.Sprintf("%04x:%04x", device.Vendor, device.Device)
+
+		var reason SkipReason
 		switch {
-		case device.Class != uint32(r.classFilter):
-			r.log.V(3).Info(
+		case !slices.Contains(r.classFilters, Class(device.Class)):
+			log.V(3).Info(
 				"Skipping device, class not matching",
-				"device", device.Name(), "expected class",
-				r.classFilter, "found class", device.Class,
+				"device", device.Name(), "id", id, "expected classes",
+				r.classFilters, "found class", device.Class,
 			)
-			continue
-		case device.Vendor != uint32(r.vendorFilter):
-			r.log.V(3).Info(
+			reason = SkipReasonClass
+		case !slices.Contains(r.vendorFilters, Vendor(device.Vendor)):
+			log.V(3).Info(
 				"Skipping device, vendor not matching",
-				"device", device.Name(), "expected vendor",
-				r.vendorFilter, "found vendor", device.Vendor,
+				"device", device.Name(), "id", id, "expected vendors",
+				r.vendorFilters, "found vendor", device.Vendor,
+			)
+			reason = SkipReasonVendor
+		case len(r.slotLabels) > 0 && !slices.Contains(r.slotLabels, slotLabel):
+			log.V(3).Info(
+				"Skipping device, slot label not matching",
+				"device", device.Name(), "id", id, "expected slot labels",
+				r.slotLabels, "found slot label", slotLabel,
 			)
+			reason = SkipReasonSlotLabel
+		case slices.Contains(r.excludedRevisions, uint8(device.Revision)):
+			log.V(3).Info(
+				"Skipping device, revision excluded",
+				"device", device.Name(), "id", id, "excluded revisions",
+				r.excludedRevisions, "found revision", device.Revision,
+			)
+			reason = SkipReasonRevision
+		case !r.hasCapabilities(log, device):
+			reason = SkipReasonCapability
+		}
+		if reason != "" {
+			report.Skipped[reason]++
+			report.SkippedDevices = append(report.SkippedDevices, SkippedDevice{
+				Address:  addressOf(device),
+				VendorID: uint16(device.Vendor),
+				DeviceID: uint16(device.Device),
+				Reason:   reason,
+			})
 			continue
 		}
 
-		r.log.V(1).Info("Found matching pci device", "device", device.Name())
-		pciDevices = append(pciDevices, Address{
-			Domain:   uint(device.Location.Segment),
-			Bus:      uint(device.Location.Bus),
-			Slot:     uint(device.Location.Device),
-			Function: uint(device.Location.Function),
+		log.V(1).Info("Found matching pci device", "device", device.Name())
+		infos = append(infos, DeviceInfo{
+			Address:    addressOf(device),
+			VendorID:   uint16(device.Vendor),
+			DeviceID:   uint16(device.Device),
+			SlotLabel:  slotLabel,
+			Revision:   uint8(device.Revision),
+			Disabled:   !r.enabled(device),
+			PowerState: powerState(device),
+			NUMANode:   numaNode(device),
+			Parent:     r.parentOf(device),
+			BARs:       r.bars(log, device),
+			Attributes: r.attributes(log, device),
 		})
+	}
 
+	slices.SortFunc(infos, func(a, b DeviceInfo) int {
+		return a.Address.Compare(b.Address)
+	})
+	slices.SortFunc(report.SkippedDevices, func(a, b SkippedDevice) int {
+		return a.Address.Compare(b.Address)
+	})
+	report.Matched = len(infos)
+
+	return infos, report, nil
+}
+
+// readSlots maps the slot address (domain:bus:slot) of every physical slot to its label.
+func (r *reader) readSlots(log logr.Logger) (map[string]string, error) {
+	slotsDir := filepath.Join(r.mountPoint, "bus", "pci", "slots")
+	entries, err := os.ReadDir(slotsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read pci slots: %w", err)
 	}
 
-	return pciDevices, nil
+	slots := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(slotsDir, entry.Name(), "address"))
+		if err != nil {
+			log.V(3).Info("Skipping pci slot without address", "slot", entry.Name())
+			continue
+		}
+		slots[strings.TrimSpace(string(data))] = entry.Name()
+	}
+
+	return slots, nil
+}
+
+// slotLabel returns the physical slot label of the device, falling back to the firmware provided label.
+func (r *reader) slotLabel(device sysfs.PciDevice, slots map[string]string) string {
+	slotAddress := fmt.Sprintf("%04x:%02x:%02x", device.Location.Segment, device.Location.Bus, device.Location.Device)
+	if label, ok := slots[slotAddress]; ok {
+		return label
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.deviceDir(device), "label"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func addressOf(device sysfs.PciDevice) Address {
+	return Address{
+		Domain:   uint(device.Location.Segment),
+		Bus:      uint(device.Location.Bus),
+		Slot:     uint(device.Location.Device),
+		Function: uint(device.Location.Function),
+	}
+}
+
+// parentOf returns the address of the upstream bridge of the device, nil if it is attached to a root bus.
+// The bridges above the device are read from its sysfs path, e.g.
+// devices/pci0000:00/<root port>/<upstream port>/<downstream port>/<device>. Devices behind a PCIe switch
+// sit below a downstream port of their own, so the upstream bridge is the one above the immediate parent,
+// i.e. the switch upstream port, and the root port for devices without a switch.
+func (r *reader) parentOf(device sysfs.PciDevice) *Address {
+	realPath, err := filepath.EvalSymlinks(r.deviceDir(device))
+	if err != nil {
+		return nil
+	}
+
+	var bridges []Address
+	for dir := filepath.Dir(realPath); !strings.HasPrefix(filepath.Base(dir), "pci"); dir = filepath.Dir(dir) {
+		address, err := ParseAddress(filepath.Base(dir))
+		if err != nil {
+			break
+		}
+		bridges = append(bridges, address)
+	}
+
+	switch len(bridges) {
+	case 0:
+		return nil
+	case 1:
+		return &bridges[0]
+	default:
+		return &bridges[1]
+	}
+}
+
+// deviceDir returns the sysfs directory of the device. PciDevice.Name does not match the directory name,
+// it separates the function by a colon.
+func (r *reader) deviceDir(device sysfs.PciDevice) string {
+	return addressOf(device).sysfsPath(r.mountPoint)
+}
+
+// enabled reads the enable attribute of the device. Devices without one are considered enabled.
+func (r *reader) enabled(device sysfs.PciDevice) bool {
+	data, err := os.ReadFile(filepath.Join(r.deviceDir(device), "enable"))
+	if err != nil {
+		return true
+	}
+	return strings.TrimSpace(string(data)) != "0"
+}
+
+// bars reads the regions of the device, nil if its resource file cannot be read.
+func (r *reader) bars(log logr.Logger, device sysfs.PciDevice) []BAR {
+	data, err := os.ReadFile(filepath.Join(r.deviceDir(device), "resource"))
+	if err != nil {
+		return nil
+	}
+
+	bars, err := ParseBARs(string(data))
+	if err != nil {
+		log.V(3).Info("Skipping invalid resource file", "device", device.Name(), "error", err)
+		return nil
+	}
+	return bars
+}
+
+// attributes reads the extra attributes of the device, omitting missing ones.
+func (r *reader) attributes(log logr.Logger, device sysfs.PciDevice) map[string]string {
+	if len(r.extraAttributes) == 0 {
+		return nil
+	}
+
+	attributes := make(map[string]string, len(r.extraAttributes))
+	for _, name := range r.extraAttributes {
+		data, err := os.ReadFile(filepath.Join(r.deviceDir(device), name))
+		if err != nil {
+			log.V(3).Info("Skipping missing attribute", "device", device.Name(), "attribute", name)
+			continue
+		}
+		attributes[name] = strings.TrimSpace(string(data))
+	}
+	return attributes
+}
+
+// hasCapabilities reports whether the device has all configured capabilities.
+func (r *reader) hasCapabilities(log logr.Logger, device sysfs.PciDevice) bool {
+	attribute := func(name string) (string, bool) {
+		data, err := os.ReadFile(filepath.Join(r.deviceDir(device), name))
+		if err != nil {
+			return "", false
+		}
+		return strings.TrimSpace(string(data)), true
+	}
+
+	for _, capability := range r.capabilities {
+		if !capability.Matches(attribute) {
+			log.V(3).Info("Skipping device, capability missing", "device", device.Name(), "capability", capability.Name)
+			return false
+		}
+	}
+	return true
+}
+
+// numaNode returns the NUMA node of the device, nil if unknown. The kernel reports -1 for devices without
+// NUMA affinity.
+func numaNode(device sysfs.PciDevice) *int {
+	if device.NumaNode == nil || *device.NumaNode < 0 {
+		return nil
+	}
+	node := int(*device.NumaNode)
+	return &node
+}
+
+func powerState(device sysfs.PciDevice) string {
+	if device.PowerState == nil {
+		return ""
+	}
+	return device.PowerState.String()
+}
+
+func (r *reader) ReadTimed() ([]Address, time.Duration, error) {
+	start := time.Now()
+	addresses, err := r.Read()
+	duration := time.Since(start)
+
+	r.log.V(1).Info("Scanned pci bus", "duration", duration)
+	return addresses, duration, err
 }
@@ -4,16 +4,28 @@
 package pci
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Class uint32
 type Vendor uint32
 
 var (
+	// Class3DController is reported by e.g. NVIDIA data center GPUs.
 	Class3DController Class = 0x030200
+	// ClassVGAController is reported by e.g. Intel GPUs and GPUs with display outputs.
+	ClassVGAController Class = 0x030000
+	// ClassDisplayController is the other display controller class reported by e.g. AMD Instinct GPUs.
+	ClassDisplayController Class = 0x038000
 
 	VendorNvidia Vendor = 0x10de
+	VendorAMD    Vendor = 0x1002
+	VendorIntel  Vendor = 0x8086
 )
 
 type Address struct {
@@ -27,6 +39,233 @@ func (p Address) String() string {
 	return fmt.Sprintf("%04x:%02x:%02x.%1x", p.Domain, p.Bus, p.Slot, p.Function)
 }
 
+// Compare orders addresses by domain, bus, slot and function. It returns -1, 0 or +1
+// as p is less than, equal to or greater than b and can be passed to slices.SortFunc.
+func (p Address) Compare(b Address) int {
+	return cmp.Or(
+		cmp.Compare(p.Domain, b.Domain),
+		cmp.Compare(p.Bus, b.Bus),
+		cmp.Compare(p.Slot, b.Slot),
+		cmp.Compare(p.Function, b.Function),
+	)
+}
+
+// Less reports whether p is ordered before b.
+func (p Address) Less(b Address) bool {
+	return p.Compare(b) < 0
+}
+
+// ParseAddress parses an address in the domain:bus:slot.function notation, e.g. 0000:17:00.0.
+func ParseAddress(s string) (Address, error) {
+	var address Address
+	if _, err := fmt.Sscanf(s, "%04x:%02x:%02x.%1x", &address.Domain, &address.Bus, &address.Slot, &address.Function); err != nil {
+		return Address{}, fmt.Errorf("invalid pci address %q: %w", s, err)
+	}
+
+	if address.String() != strings.ToLower(s) {
+		return Address{}, fmt.Errorf("invalid pci address %q", s)
+	}
+
+	return address, nil
+}
+
+// Reader reads pci addresses of matching devices, sorted by domain, bus, slot and function.
 type Reader interface {
 	Read() ([]Address, error)
 }
+
+// DeviceInfo describes a discovered pci device.
+type DeviceInfo struct {
+	Address Address
+	// VendorID and DeviceID identify the model of the device, e.g. 10de:2204 for an NVIDIA RTX 3090.
+	VendorID uint16
+	DeviceID uint16
+	// SlotLabel is the physical slot label of the device, empty if unknown.
+	SlotLabel string
+	// Revision is the silicon revision of the device.
+	Revision uint8
+	// Disabled reports whether the device is disabled. Devices not reporting it are considered enabled.
+	Disabled bool
+	// PowerState is the power state of the device, e.g. D0 or D3hot, empty if unknown.
+	PowerState string
+	// NUMANode is the NUMA node the device is attached to, nil if unknown.
+	NUMANode *int
+	// Parent is the address of the upstream bridge of the device, the upstream port of the PCIe switch
+	// it is attached to or else its root port, nil if the device is attached to a root bus. Devices
+	// behind the same switch share their Parent.
+	Parent *Address
+	// BARs are the regions listed by the sysfs resource file of the device, indexed like the kernel
+	// does: the six BARs, the expansion ROM, then e.g. SR-IOV and bridge windows. Unused regions are
+	// zero, BARs is nil if the file could not be read.
+	BARs []BAR
+	// Attributes holds the extra sysfs attributes requested via ReaderOptions.ExtraAttributes.
+	// Attributes the device does not expose are omitted.
+	Attributes map[string]string
+}
+
+// BAR is a memory or I/O region of a device.
+type BAR struct {
+	Start uint64
+	End   uint64
+	// Flags are the kernel resource flags of the region, see IORESOURCE_* in linux/ioport.h.
+	Flags uint64
+}
+
+const (
+	BARFlagIO       uint64 = 0x00000100
+	BARFlagMemory   uint64 = 0x00000200
+	BARFlagPrefetch uint64 = 0x00002000
+	BARFlagMem64    uint64 = 0x00100000
+)
+
+// Size returns the size of the region in bytes, 0 if the region is unused.
+func (b BAR) Size() uint64 {
+	if b.End == 0 {
+		return 0
+	}
+	return b.End - b.Start + 1
+}
+
+// ParseBARs parses the content of the sysfs resource file of a device, listing the start, end and
+// flags of one region per line.
+func ParseBARs(data string) ([]BAR, error) {
+	var bars []BAR
+	for i, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid resource line %d: %q", i, line)
+		}
+
+		var values [3]uint64
+		for j, field := range fields {
+			value, err := strconv.ParseUint(field, 0, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid resource line %d: %w", i, err)
+			}
+			values[j] = value
+		}
+		bars = append(bars, BAR{Start: values[0], End: values[1], Flags: values[2]})
+	}
+	return bars, nil
+}
+
+// InfoReader is implemented by readers that report details of the discovered devices.
+type InfoReader interface {
+	Reader
+	ReadInfo() ([]DeviceInfo, error)
+}
+
+// DefaultMountPoint is the default mount point of sysfs.
+const DefaultMountPoint = "/sys"
+
+// ReaderOptions defines options to initialize the pci reader.
+type ReaderOptions struct {
+	// MountPoint is the mount point of sysfs. Defaults to /sys.
+	MountPoint string
+	Vendor     Vendor
+	Class      Class
+	// Vendors and Classes are matched in addition to Vendor and Class if set, e.g. for nodes with
+	// accelerators of several vendors. Vendors and classes are matched independently, a device
+	// matches if its vendor is one of the vendors and its class one of the classes, so every
+	// combination of them is read.
+	Vendors []Vendor
+	Classes []Class
+	// SlotLabels, if set, restricts the reader to devices in one of the given physical slots.
+	SlotLabels []string
+	// ExcludedRevisions excludes devices of the given silicon revisions.
+	ExcludedRevisions []uint8
+	// ExtraAttributes are additional sysfs attribute files of the device to read into DeviceInfo.Attributes.
+	ExtraAttributes []string
+	// Capabilities, if set, restricts the reader to devices having all of the given capabilities.
+	Capabilities []Capability
+	// EmptyIfUnavailable makes the reader report no devices instead of failing the construction if sysfs
+	// cannot be opened, e.g. in sandboxes without sysfs running workloads without pci devices.
+	EmptyIfUnavailable bool
+}
+
+func (o *ReaderOptions) Defaults() {
+	if o.MountPoint == "" {
+		o.MountPoint = DefaultMountPoint
+	}
+}
+
+// Capability is a predicate on the sysfs attributes of a device.
+type Capability struct {
+	// Name identifies the capability in logs.
+	Name string
+	// Matches reports whether a device has the capability. attribute returns the trimmed content of
+	// the sysfs attribute file of the device with the given name, ok is false if it does not exist.
+	Matches func(attribute func(name string) (value string, ok bool)) bool
+}
+
+// CapabilitySRIOV matches devices supporting at least one SR-IOV virtual function.
+var CapabilitySRIOV = Capability{
+	Name: "SRIOV",
+	Matches: func(attribute func(name string) (string, bool)) bool {
+		value, ok := attribute("sriov_totalvfs")
+		if !ok {
+			return false
+		}
+		totalVFs, err := strconv.Atoi(value)
+		return err == nil && totalVFs > 0
+	},
+}
+
+// SkipReason is the reason a scanned device was not matched by the reader.
+type SkipReason string
+
+const (
+	SkipReasonClass      SkipReason = "Class"
+	SkipReasonVendor     SkipReason = "Vendor"
+	SkipReasonSlotLabel  SkipReason = "SlotLabel"
+	SkipReasonRevision   SkipReason = "Revision"
+	SkipReasonCapability SkipReason = "Capability"
+)
+
+// ScanReport summarizes a bus scan. Disabled devices are matched, see DeviceInfo.Disabled.
+type ScanReport struct {
+	// Scanned is the number of devices found on the bus.
+	Scanned int
+	// Matched is the number of devices returned.
+	Matched int
+	// Skipped counts the not matched devices by the first filter they failed.
+	Skipped map[SkipReason]int
+	// SkippedDevices lists the not matched devices ordered by address.
+	SkippedDevices []SkippedDevice
+}
+
+// SkippedDevice is a scanned device not matched by the reader.
+type SkippedDevice struct {
+	Address  Address
+	VendorID uint16
+	DeviceID uint16
+	// Reason is the first filter the device failed.
+	Reason SkipReason
+}
+
+// ID returns the vendor and device id of the device in the vendor:device notation, e.g. 10de:2204.
+func (d SkippedDevice) ID() string {
+	return fmt.Sprintf("%04x:%04x", d.VendorID, d.DeviceID)
+}
+
+func (d SkippedDevice) String() string {
+	return fmt.Sprintf("%s (%s)", d.Address, d.ID())
+}
+
+// ReportReader is implemented by readers that report why devices were not matched.
+type ReportReader interface {
+	Reader
+	ReadWithReport() ([]Address, ScanReport, error)
+}
+
+// ContextReader is implemented by readers that log via the logger of the given context.
+type ContextReader interface {
+	Reader
+	ReadContext(ctx context.Context) ([]Address, error)
+}
+
+// TimedReader is implemented by readers that report how long a bus scan took.
+type TimedReader interface {
+	Reader
+	ReadTimed() ([]Address, time.Duration, error)
+}
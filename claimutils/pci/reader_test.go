@@ -7,18 +7,30 @@
 package pci_test
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	"github.com/ironcore-dev/provider-utils/claimutils/pci"
 	log "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func writeFakePCIDevice(t *testing.T, sysRoot, id string, vals map[string]string) {
 	t.Helper()
+	writeFakePCIDeviceBelow(t, sysRoot, "pci0000:00", id, vals)
+}
+
+// writeFakePCIDeviceBelow writes the device below parent, the path of its bridges below devices.
+func writeFakePCIDeviceBelow(t *testing.T, sysRoot, parent, id string, vals map[string]string) {
+	t.Helper()
 
-	parent := "pci0000:00"
 	devDir := filepath.Join(sysRoot, "devices", parent, id)
 	if err := os.MkdirAll(devDir, 0o755); err != nil {
 		t.Fatalf("mkdir %s: %v", devDir, err)
@@ -34,10 +46,12 @@ func writeFakePCIDevice(t *testing.T, sysRoot, id string, vals map[string]string
 	}
 
 	for _, f := range required {
-		val, ok := vals[f]
-		if !ok {
+		if _, ok := vals[f]; !ok {
 			t.Fatalf("missing required %s in vals", f)
 		}
+	}
+	// optional attributes like resource are written as given
+	for f, val := range vals {
 		path := filepath.Join(devDir, f)
 		if err := os.WriteFile(path, []byte(val+"\n"), 0o644); err != nil {
 			t.Fatalf("write %s: %v", path, err)
@@ -108,3 +122,721 @@ func TestPCIReader_ReadFilters(t *testing.T) {
 		t.Fatalf("expected %d devices, got %d: %+v", want, got, devices)
 	}
 }
+
+func TestPCIReader_ReadTimed(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for i := 0; i < 64; i++ {
+		writeFakePCIDevice(t, tmpDir, fmt.Sprintf("0000:%02x:00.0", i), map[string]string{
+			"class":            "0x030200",
+			"vendor":           "0x10de",
+			"device":           "0x2901",
+			"subsystem_vendor": "0x10de",
+			"subsystem_device": "0x0001",
+			"revision":         "0x1",
+		})
+	}
+
+	logger := log.Log.WithName("pci-test")
+
+	reader, err := pci.NewReaderWithMount(logger, tmpDir, pci.VendorNvidia, pci.Class3DController)
+	if err != nil {
+		t.Fatalf("NewReaderWithMount: %v", err)
+	}
+
+	var timedReader pci.TimedReader = reader
+	devices, duration, err := timedReader.ReadTimed()
+	if err != nil {
+		t.Fatalf("ReadTimed: %v", err)
+	}
+
+	if got, want := len(devices), 64; got != want {
+		t.Fatalf("expected %d devices, got %d", want, got)
+	}
+
+	if duration <= 0 {
+		t.Fatalf("expected non-zero scan duration, got %s", duration)
+	}
+}
+
+func TestPCIReader_ReadSorted(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, id := range []string{"0001:00:00.0", "0000:97:00.1", "0000:17:01.0", "0000:97:00.0", "0000:17:00.0"} {
+		writeFakePCIDevice(t, tmpDir, id, map[string]string{
+			"class":            "0x030200",
+			"vendor":           "0x10de",
+			"device":           "0x2901",
+			"subsystem_vendor": "0x10de",
+			"subsystem_device": "0x0001",
+			"revision":         "0x1",
+		})
+	}
+
+	logger := log.Log.WithName("pci-test")
+
+	reader, err := pci.NewReaderWithMount(logger, tmpDir, pci.VendorNvidia, pci.Class3DController)
+	if err != nil {
+		t.Fatalf("NewReaderWithMount: %v", err)
+	}
+
+	devices, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	want := []pci.Address{
+		{Domain: 0, Bus: 0x17, Slot: 0, Function: 0},
+		{Domain: 0, Bus: 0x17, Slot: 1, Function: 0},
+		{Domain: 0, Bus: 0x97, Slot: 0, Function: 0},
+		{Domain: 0, Bus: 0x97, Slot: 0, Function: 1},
+		{Domain: 1, Bus: 0, Slot: 0, Function: 0},
+	}
+	if !slices.Equal(devices, want) {
+		t.Fatalf("expected sorted devices %v, got %v", want, devices)
+	}
+}
+
+func writeFakePCISlot(t *testing.T, sysRoot, name, address string) {
+	t.Helper()
+
+	slotDir := filepath.Join(sysRoot, "bus", "pci", "slots", name)
+	if err := os.MkdirAll(slotDir, 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", slotDir, err)
+	}
+
+	path := filepath.Join(slotDir, "address")
+	if err := os.WriteFile(path, []byte(address+"\n"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestPCIReader_ReadSlotLabels(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, id := range []string{"0000:17:00.0", "0000:97:00.0", "0000:ca:00.0"} {
+		writeFakePCIDevice(t, tmpDir, id, map[string]string{
+			"class":            "0x030200",
+			"vendor":           "0x10de",
+			"device":           "0x2901",
+			"subsystem_vendor": "0x10de",
+			"subsystem_device": "0x0001",
+			"revision":         "0x1",
+		})
+	}
+	writeFakePCISlot(t, tmpDir, "1", "0000:17:00")
+	writeFakePCISlot(t, tmpDir, "3", "0000:97:00")
+
+	logger := log.Log.WithName("pci-test")
+
+	reader, err := pci.NewReaderWithOptions(logger, pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+
+	wantLabels := []string{"1", "3", ""}
+	if got, want := len(infos), len(wantLabels); got != want {
+		t.Fatalf("expected %d devices, got %d: %+v", want, got, infos)
+	}
+	for i, info := range infos {
+		if info.SlotLabel != wantLabels[i] {
+			t.Fatalf("expected slot label %q for %s, got %q", wantLabels[i], info.Address, info.SlotLabel)
+		}
+	}
+
+	reader, err = pci.NewReaderWithOptions(logger, pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+		SlotLabels: []string{"3"},
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	devices, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	want := []pci.Address{{Bus: 0x97}}
+	if !slices.Equal(devices, want) {
+		t.Fatalf("expected devices %v in slot 3, got %v", want, devices)
+	}
+}
+
+func TestPCIReader_ReadRevisions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for id, revision := range map[string]string{"0000:17:00.0": "0xa0", "0000:97:00.0": "0xa1"} {
+		writeFakePCIDevice(t, tmpDir, id, map[string]string{
+			"class":            "0x030200",
+			"vendor":           "0x10de",
+			"device":           "0x2901",
+			"subsystem_vendor": "0x10de",
+			"subsystem_device": "0x0001",
+			"revision":         revision,
+		})
+	}
+
+	logger := log.Log.WithName("pci-test")
+
+	reader, err := pci.NewReaderWithOptions(logger, pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+
+	wantRevisions := []uint8{0xa0, 0xa1}
+	if got, want := len(infos), len(wantRevisions); got != want {
+		t.Fatalf("expected %d devices, got %d: %+v", want, got, infos)
+	}
+	for i, info := range infos {
+		if info.Revision != wantRevisions[i] {
+			t.Fatalf("expected revision %#x for %s, got %#x", wantRevisions[i], info.Address, info.Revision)
+		}
+	}
+
+	reader, err = pci.NewReaderWithOptions(logger, pci.ReaderOptions{
+		MountPoint:        tmpDir,
+		Vendor:            pci.VendorNvidia,
+		Class:             pci.Class3DController,
+		ExcludedRevisions: []uint8{0xa0},
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	devices, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	want := []pci.Address{{Bus: 0x97}}
+	if !slices.Equal(devices, want) {
+		t.Fatalf("expected %v, got %v", want, devices)
+	}
+}
+
+func TestPCIReader_ReadEnabled(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, id := range []string{"0000:17:00.0", "0000:97:00.0"} {
+		writeFakePCIDevice(t, tmpDir, id, map[string]string{
+			"class":            "0x030200",
+			"vendor":           "0x10de",
+			"device":           "0x2901",
+			"subsystem_vendor": "0x10de",
+			"subsystem_device": "0x0001",
+			"revision":         "0x1",
+		})
+	}
+	enablePath := filepath.Join(tmpDir, "devices", "pci0000:00", "0000:97:00.0", "enable")
+	if err := os.WriteFile(enablePath, []byte("0\n"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", enablePath, err)
+	}
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+
+	wantDisabled := []bool{false, true}
+	if got, want := len(infos), len(wantDisabled); got != want {
+		t.Fatalf("expected %d devices, got %d: %+v", want, got, infos)
+	}
+	for i, info := range infos {
+		if info.Disabled != wantDisabled[i] {
+			t.Fatalf("expected disabled %t for %s, got %t", wantDisabled[i], info.Address, info.Disabled)
+		}
+	}
+}
+
+func TestPCIReader_ReadExtraAttributes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, id := range []string{"0000:17:00.0", "0000:97:00.0"} {
+		writeFakePCIDevice(t, tmpDir, id, map[string]string{
+			"class":            "0x030200",
+			"vendor":           "0x10de",
+			"device":           "0x2901",
+			"subsystem_vendor": "0x10de",
+			"subsystem_device": "0x0001",
+			"revision":         "0x1",
+		})
+	}
+	topologyPath := filepath.Join(tmpDir, "devices", "pci0000:00", "0000:17:00.0", "topology_id")
+	if err := os.WriteFile(topologyPath, []byte("7\n"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", topologyPath, err)
+	}
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint:      tmpDir,
+		Vendor:          pci.VendorNvidia,
+		Class:           pci.Class3DController,
+		ExtraAttributes: []string{"topology_id"},
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 devices, got %d: %+v", len(infos), infos)
+	}
+
+	if got := infos[0].Attributes["topology_id"]; got != "7" {
+		t.Fatalf("expected topology_id 7 for %s, got %q", infos[0].Address, got)
+	}
+	if _, ok := infos[1].Attributes["topology_id"]; ok {
+		t.Fatalf("expected missing topology_id to be omitted for %s, got %v", infos[1].Address, infos[1].Attributes)
+	}
+}
+
+func TestPCIReader_ReadNUMANode(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, id := range []string{"0000:17:00.0", "0000:97:00.0"} {
+		writeFakePCIDevice(t, tmpDir, id, map[string]string{
+			"class":            "0x030200",
+			"vendor":           "0x10de",
+			"device":           "0x2901",
+			"subsystem_vendor": "0x10de",
+			"subsystem_device": "0x0001",
+			"revision":         "0x1",
+		})
+	}
+	numaNodePath := filepath.Join(tmpDir, "devices", "pci0000:00", "0000:17:00.0", "numa_node")
+	if err := os.WriteFile(numaNodePath, []byte("1\n"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", numaNodePath, err)
+	}
+	// the kernel reports -1 for devices without numa affinity
+	numaNodePath = filepath.Join(tmpDir, "devices", "pci0000:00", "0000:97:00.0", "numa_node")
+	if err := os.WriteFile(numaNodePath, []byte("-1\n"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", numaNodePath, err)
+	}
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 devices, got %d: %+v", len(infos), infos)
+	}
+	if infos[0].NUMANode == nil || *infos[0].NUMANode != 1 {
+		t.Fatalf("expected numa node 1 for %s, got %v", infos[0].Address, infos[0].NUMANode)
+	}
+	if infos[1].NUMANode != nil {
+		t.Fatalf("expected unknown numa node for %s, got %d", infos[1].Address, *infos[1].NUMANode)
+	}
+}
+
+func TestPCIReader_ReadParent(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	vals := map[string]string{
+		"class":            "0x030200",
+		"vendor":           "0x10de",
+		"device":           "0x2901",
+		"subsystem_vendor": "0x10de",
+		"subsystem_device": "0x0001",
+		"revision":         "0x1",
+	}
+	// root port 00:01.0 -> switch upstream port 15:00.0 -> downstream ports 16:00.0, 16:01.0 -> gpus
+	switchPorts := "pci0000:00/0000:00:01.0/0000:15:00.0"
+	writeFakePCIDeviceBelow(t, tmpDir, switchPorts+"/0000:16:00.0", "0000:17:00.0", vals)
+	writeFakePCIDeviceBelow(t, tmpDir, switchPorts+"/0000:16:01.0", "0000:18:00.0", vals)
+	// root port 00:02.0 -> gpu
+	writeFakePCIDeviceBelow(t, tmpDir, "pci0000:00/0000:00:02.0", "0000:97:00.0", vals)
+	writeFakePCIDevice(t, tmpDir, "0000:98:00.0", vals)
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+
+	upstreamPort := &pci.Address{Bus: 0x15}
+	rootPort := &pci.Address{Slot: 2}
+	expected := map[pci.Address]*pci.Address{
+		{Bus: 0x17}: upstreamPort,
+		{Bus: 0x18}: upstreamPort,
+		{Bus: 0x97}: rootPort,
+		{Bus: 0x98}: nil,
+	}
+	if len(infos) != len(expected) {
+		t.Fatalf("expected %d devices, got %d: %+v", len(expected), len(infos), infos)
+	}
+	for _, info := range infos {
+		if want := expected[info.Address]; !reflect.DeepEqual(info.Parent, want) {
+			t.Fatalf("expected parent %v for %s, got %v", want, info.Address, info.Parent)
+		}
+	}
+}
+
+func TestPCIReader_ReadBARs(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFakePCIDevice(t, tmpDir, "0000:17:00.0", map[string]string{
+		"class":            "0x030200",
+		"vendor":           "0x10de",
+		"device":           "0x2901",
+		"subsystem_vendor": "0x10de",
+		"subsystem_device": "0x0001",
+		"revision":         "0x1",
+		"resource": strings.Join([]string{
+			"0x0000000091000000 0x0000000091ffffff 0x0000000000040200",
+			"0x0000000000000000 0x0000000000000000 0x0000000000000000",
+			"0x000021e000000000 0x000021ffffffffff 0x000000000014220c",
+		}, "\n"),
+	})
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+	if len(infos) != 1 {
+		t.Fatalf("expected 1 device, got %d: %+v", len(infos), infos)
+	}
+
+	expected := []pci.BAR{
+		{Start: 0x91000000, End: 0x91ffffff, Flags: 0x40200},
+		{},
+		{Start: 0x21e000000000, End: 0x21ffffffffff, Flags: 0x14220c},
+	}
+	if !reflect.DeepEqual(infos[0].BARs, expected) {
+		t.Fatalf("expected bars %+v, got %+v", expected, infos[0].BARs)
+	}
+	if got, want := infos[0].BARs[0].Size(), uint64(16<<20); got != want {
+		t.Fatalf("expected size %d of bar 0, got %d", want, got)
+	}
+	if got := infos[0].BARs[1].Size(); got != 0 {
+		t.Fatalf("expected unused bar 1, got size %d", got)
+	}
+	if flags := infos[0].BARs[2].Flags; flags&pci.BARFlagMem64 == 0 || flags&pci.BARFlagPrefetch == 0 {
+		t.Fatalf("expected prefetchable 64 bit bar 2, got flags %#x", flags)
+	}
+}
+
+func TestPCIReader_ReadWithReport(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// class, vendor, device and revision of the fake devices
+	for id, vals := range map[string][4]string{
+		"0000:17:00.0": {"0x030200", "0x10de", "0x2204", "0xa1"},
+		"0000:97:00.0": {"0x030200", "0x10de", "0x2204", "0xa1"},
+		"0000:98:00.0": {"0x030200", "0x10de", "0x2204", "0xa0"},
+		"0000:99:00.0": {"0x030200", "0x1002", "0x740f", "0xa1"},
+		"0000:00:00.0": {"0x060000", "0x8086", "0x09a2", "0xa1"},
+		"0000:00:01.0": {"0x060400", "0x8086", "0x347a", "0xa1"},
+	} {
+		writeFakePCIDevice(t, tmpDir, id, map[string]string{
+			"class":            vals[0],
+			"vendor":           vals[1],
+			"device":           vals[2],
+			"subsystem_vendor": vals[1],
+			"subsystem_device": "0x0001",
+			"revision":         vals[3],
+		})
+	}
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint:        tmpDir,
+		Vendor:            pci.VendorNvidia,
+		Class:             pci.Class3DController,
+		ExcludedRevisions: []uint8{0xa0},
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	devices, report, err := reader.ReadWithReport()
+	if err != nil {
+		t.Fatalf("ReadWithReport: %v", err)
+	}
+
+	want := []pci.Address{{Bus: 0x17}, {Bus: 0x97}}
+	if !slices.Equal(devices, want) {
+		t.Fatalf("expected %v, got %v", want, devices)
+	}
+
+	wantReport := pci.ScanReport{
+		Scanned: 6,
+		Matched: 2,
+		Skipped: map[pci.SkipReason]int{
+			pci.SkipReasonClass:    2,
+			pci.SkipReasonVendor:   1,
+			pci.SkipReasonRevision: 1,
+		},
+		SkippedDevices: []pci.SkippedDevice{
+			{Address: pci.Address{}, VendorID: 0x8086, DeviceID: 0x09a2, Reason: pci.SkipReasonClass},
+			{Address: pci.Address{Slot: 1}, VendorID: 0x8086, DeviceID: 0x347a, Reason: pci.SkipReasonClass},
+			{Address: pci.Address{Bus: 0x98}, VendorID: 0x10de, DeviceID: 0x2204, Reason: pci.SkipReasonRevision},
+			{Address: pci.Address{Bus: 0x99}, VendorID: 0x1002, DeviceID: 0x740f, Reason: pci.SkipReasonVendor},
+		},
+	}
+	if !reflect.DeepEqual(report, wantReport) {
+		t.Fatalf("expected report %+v, got %+v", wantReport, report)
+	}
+	if got, want := report.SkippedDevices[3].String(), "0000:99:00.0 (1002:740f)"; got != want {
+		t.Fatalf("expected skipped device %q, got %q", want, got)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+	if infos[0].VendorID != 0x10de || infos[0].DeviceID != 0x2204 {
+		t.Fatalf("expected device 10de:2204, got %04x:%04x", infos[0].VendorID, infos[0].DeviceID)
+	}
+}
+
+func TestPCIReader_ReadContext(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFakePCIDevice(t, tmpDir, "0000:17:00.0", map[string]string{
+		"class":            "0x030200",
+		"vendor":           "0x10de",
+		"device":           "0x2901",
+		"subsystem_vendor": "0x10de",
+		"subsystem_device": "0x0001",
+		"revision":         "0xa1",
+	})
+
+	var constructorLines []string
+	constructorLogger := funcr.New(func(prefix, args string) {
+		constructorLines = append(constructorLines, args)
+	}, funcr.Options{Verbosity: 3})
+
+	reader, err := pci.NewReaderWithOptions(constructorLogger, pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	var contextLines []string
+	contextLogger := funcr.New(func(prefix, args string) {
+		contextLines = append(contextLines, args)
+	}, funcr.Options{Verbosity: 3}).WithValues("requestID", "req-1")
+
+	devices, err := reader.ReadContext(logr.NewContext(context.Background(), contextLogger))
+	if err != nil {
+		t.Fatalf("ReadContext: %v", err)
+	}
+	if want := []pci.Address{{Bus: 0x17}}; !slices.Equal(devices, want) {
+		t.Fatalf("expected %v, got %v", want, devices)
+	}
+
+	if len(constructorLines) != 0 {
+		t.Fatalf("expected no logs via the constructor logger, got %v", constructorLines)
+	}
+	if len(contextLines) == 0 {
+		t.Fatal("expected logs via the context logger")
+	}
+	for _, line := range contextLines {
+		if !strings.Contains(line, `"requestID"="req-1"`) {
+			t.Fatalf("expected log line to carry the context fields, got %s", line)
+		}
+	}
+
+	if _, err := reader.ReadContext(context.Background()); err != nil {
+		t.Fatalf("ReadContext: %v", err)
+	}
+	if len(constructorLines) == 0 {
+		t.Fatal("expected logs via the constructor logger without context logger")
+	}
+}
+
+func TestPCIReader_ReadCapabilities(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for id, totalVFs := range map[string]string{"0000:17:00.0": "8", "0000:97:00.0": "0"} {
+		writeFakePCIDevice(t, tmpDir, id, map[string]string{
+			"class":            "0x020000",
+			"vendor":           "0x15b3",
+			"device":           "0x101d",
+			"subsystem_vendor": "0x15b3",
+			"subsystem_device": "0x0001",
+			"revision":         "0x0",
+		})
+		totalVFsPath := filepath.Join(tmpDir, "devices", "pci0000:00", id, "sriov_totalvfs")
+		if err := os.WriteFile(totalVFsPath, []byte(totalVFs+"\n"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", totalVFsPath, err)
+		}
+	}
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint:   tmpDir,
+		Vendor:       0x15b3,
+		Class:        0x020000,
+		Capabilities: []pci.Capability{pci.CapabilitySRIOV},
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	devices, report, err := reader.ReadWithReport()
+	if err != nil {
+		t.Fatalf("ReadWithReport: %v", err)
+	}
+
+	want := []pci.Address{{Bus: 0x17}}
+	if !slices.Equal(devices, want) {
+		t.Fatalf("expected %v, got %v", want, devices)
+	}
+	if got := report.Skipped[pci.SkipReasonCapability]; got != 1 {
+		t.Fatalf("expected 1 device skipped for a missing capability, got %d", got)
+	}
+}
+
+func TestPCIReader_EmptyIfUnavailable(t *testing.T) {
+	mountPoint := filepath.Join(t.TempDir(), "missing")
+
+	if _, err := pci.NewReaderWithMount(log.Log.WithName("pci-test"), mountPoint, pci.VendorNvidia, pci.Class3DController); err == nil {
+		t.Fatalf("expected an error opening a missing sysfs")
+	}
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint:         mountPoint,
+		Vendor:             pci.VendorNvidia,
+		Class:              pci.Class3DController,
+		EmptyIfUnavailable: true,
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	devices, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(devices) != 0 {
+		t.Fatalf("expected no devices, got %v", devices)
+	}
+
+	infos, err := reader.ReadInfo()
+	if err != nil {
+		t.Fatalf("ReadInfo: %v", err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("expected no device infos, got %v", infos)
+	}
+}
+
+func TestPCIReader_ReadMultiVendor(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for id, vals := range map[string]map[string]string{
+		"0000:17:00.0": {"class": "0x030200", "vendor": "0x10de", "device": "0x2901"},
+		"0000:27:00.0": {"class": "0x038000", "vendor": "0x1002", "device": "0x74a1"},
+		"0000:37:00.0": {"class": "0x030000", "vendor": "0x8086", "device": "0x0bd5"},
+		"0000:47:00.0": {"class": "0x020000", "vendor": "0x8086", "device": "0x1593"},
+		"0000:57:00.0": {"class": "0x030000", "vendor": "0x10de", "device": "0x1eb8"},
+	} {
+		vals["subsystem_vendor"] = vals["vendor"]
+		vals["subsystem_device"] = "0x0001"
+		vals["revision"] = "0x1"
+		writeFakePCIDevice(t, tmpDir, id, vals)
+	}
+
+	reader, err := pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendor:     pci.VendorNvidia,
+		Class:      pci.Class3DController,
+		Vendors:    []pci.Vendor{pci.VendorAMD, pci.VendorIntel},
+		Classes:    []pci.Class{pci.ClassDisplayController, pci.ClassVGAController},
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	devices, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	// the Intel network controller is skipped by class, the Nvidia VGA controller matches as vendors
+	// and classes are combined
+	expected := []pci.Address{{Bus: 0x17}, {Bus: 0x27}, {Bus: 0x37}, {Bus: 0x57}}
+	if !slices.Equal(devices, expected) {
+		t.Fatalf("expected %v, got %v", expected, devices)
+	}
+
+	reader, err = pci.NewReaderWithOptions(log.Log.WithName("pci-test"), pci.ReaderOptions{
+		MountPoint: tmpDir,
+		Vendors:    []pci.Vendor{pci.VendorIntel},
+		Classes:    []pci.Class{pci.ClassVGAController},
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithOptions: %v", err)
+	}
+
+	devices, err = reader.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	// without Vendor and Class only the listed vendors and classes are matched
+	expected = []pci.Address{{Bus: 0x37}}
+	if !slices.Equal(devices, expected) {
+		t.Fatalf("expected %v, got %v", expected, devices)
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// RecordingReader writes the result of every Read of an inner reader to a file that
+// can be replayed with a ReplayReader, e.g. to reproduce a production device topology.
+type RecordingReader struct {
+	inner Reader
+	path  string
+}
+
+func NewRecordingReader(inner Reader, path string) *RecordingReader {
+	return &RecordingReader{
+		inner: inner,
+		path:  path,
+	}
+}
+
+// Read reads the devices of the inner reader and records them, failed reads are not recorded.
+func (r *RecordingReader) Read() ([]Address, error) {
+	devices, err := r.inner.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(devices))
+	for _, device := range devices {
+		addresses = append(addresses, device.String())
+	}
+
+	data, err := json.MarshalIndent(addresses, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recording: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write recording %s: %w", r.path, err)
+	}
+
+	return devices, nil
+}
+
+// ReplayReader returns the devices recorded by a RecordingReader.
+type ReplayReader struct {
+	path string
+}
+
+func NewReplayReader(path string) *ReplayReader {
+	return &ReplayReader{
+		path: path,
+	}
+}
+
+// Read returns the devices of the latest recording.
+func (r *ReplayReader) Read() ([]Address, error) {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %w", r.path, err)
+	}
+
+	var addresses []string
+	if err := json.Unmarshal(data, &addresses); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal recording %s: %w", r.path, err)
+	}
+
+	devices := make([]Address, 0, len(addresses))
+	for _, address := range addresses {
+		device, err := ParseAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recording %s: %w", r.path, err)
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
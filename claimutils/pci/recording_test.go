@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+func TestRecordingReader_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topology.json")
+	devices := []pci.Address{
+		{Domain: 0, Bus: 0x17, Slot: 0, Function: 0},
+		{Domain: 0, Bus: 0x97, Slot: 1, Function: 2},
+		{Domain: 1, Bus: 0x00, Slot: 0x1f, Function: 7},
+	}
+
+	recorded, err := pci.NewRecordingReader(&fakeHotplugReader{devices: devices}, path).Read()
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if !slices.Equal(recorded, devices) {
+		t.Fatalf("expected recording reader to pass through %v, got %v", devices, recorded)
+	}
+
+	replayed, err := pci.NewReplayReader(path).Read()
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if !slices.Equal(replayed, devices) {
+		t.Fatalf("expected replayed devices %v, got %v", devices, replayed)
+	}
+}
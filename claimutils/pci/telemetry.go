@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoTelemetry = errors.New("device reports no telemetry")
+)
+
+// Telemetry is the live load of a device.
+type Telemetry struct {
+	// UtilizationPercent is the utilization of the device from 0 to 100, -1 if unknown.
+	UtilizationPercent float64
+	// TemperatureC is the temperature of the device in degrees Celsius, -1 if unknown.
+	TemperatureC float64
+}
+
+// TelemetrySource reads the telemetry of devices, e.g. via hwmon sysfs or nvml.
+type TelemetrySource interface {
+	Telemetry(address Address) (Telemetry, error)
+}
+
+// HwmonTelemetrySource reads the temperature of devices from their hwmon sysfs sensor and the
+// utilization from the gpu_busy_percent attribute exposed by e.g. amdgpu.
+type HwmonTelemetrySource struct {
+	// MountPoint is the mount point of sysfs. Defaults to /sys.
+	MountPoint string
+}
+
+func (s HwmonTelemetrySource) Telemetry(address Address) (Telemetry, error) {
+	mountPoint := s.MountPoint
+	if mountPoint == "" {
+		mountPoint = DefaultMountPoint
+	}
+	deviceDir := address.sysfsPath(mountPoint)
+
+	telemetry := Telemetry{UtilizationPercent: -1, TemperatureC: -1}
+	if busy, err := readFloat(filepath.Join(deviceDir, "gpu_busy_percent")); err == nil {
+		telemetry.UtilizationPercent = busy
+	}
+
+	sensors, _ := filepath.Glob(filepath.Join(deviceDir, "hwmon", "hwmon*", "temp1_input"))
+	for _, sensor := range sensors {
+		if milliC, err := readFloat(sensor); err == nil {
+			telemetry.TemperatureC = milliC / 1000
+			break
+		}
+	}
+
+	if telemetry.UtilizationPercent < 0 && telemetry.TemperatureC < 0 {
+		return Telemetry{}, fmt.Errorf("%w: %s", ErrNoTelemetry, address)
+	}
+	return telemetry, nil
+}
+
+func readFloat(path string) (float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+}
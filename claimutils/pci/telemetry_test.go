@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package pci_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/claimutils/pci"
+)
+
+func TestHwmonTelemetrySource(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	devDir := filepath.Join(tmpDir, "bus", "pci", "devices", "0000:17:00.0")
+	hwmonDir := filepath.Join(devDir, "hwmon", "hwmon3")
+	if err := os.MkdirAll(hwmonDir, 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", hwmonDir, err)
+	}
+	if err := os.WriteFile(filepath.Join(hwmonDir, "temp1_input"), []byte("54000\n"), 0o644); err != nil {
+		t.Fatalf("write temp1_input: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(devDir, "gpu_busy_percent"), []byte("37\n"), 0o644); err != nil {
+		t.Fatalf("write gpu_busy_percent: %v", err)
+	}
+
+	source := pci.HwmonTelemetrySource{MountPoint: tmpDir}
+	telemetry, err := source.Telemetry(pci.Address{Bus: 0x17})
+	if err != nil {
+		t.Fatalf("Telemetry: %v", err)
+	}
+	expected := pci.Telemetry{UtilizationPercent: 37, TemperatureC: 54}
+	if telemetry != expected {
+		t.Fatalf("expected telemetry %+v, got %+v", expected, telemetry)
+	}
+
+	if _, err := source.Telemetry(pci.Address{Bus: 0x97}); !errors.Is(err, pci.ErrNoTelemetry) {
+		t.Fatalf("expected %v for device without sensors, got %v", pci.ErrNoTelemetry, err)
+	}
+}
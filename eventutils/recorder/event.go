@@ -5,13 +5,18 @@ package recorder
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
 )
 
 // EventRecorder defines an interface for recording events
@@ -24,19 +29,48 @@ type EventStore interface {
 	ListEvents() []*Event
 }
 
+// minEventsCapacity is the capacity the backing array of a store is never shrunk below.
+const minEventsCapacity = 16
+
+// NodeScopeID is the involved object id of events that don't correspond to a specific object.
+const NodeScopeID = "node"
+
 type Event struct {
 	InvolvedObjectMeta api.Metadata
 	Type               string
 	Reason             string
 	Message            string
 	EventTime          int64
+	// Fields are structured fields of the event, e.g. the volume an attach event refers to.
+	Fields map[string]string `json:",omitempty"`
 }
 
+// FullPolicy defines how a full store handles new events.
+type FullPolicy string
+
+const (
+	// FullPolicyOverwriteOldest overwrites the oldest event with the new one.
+	FullPolicyOverwriteOldest FullPolicy = "OverwriteOldest"
+	// FullPolicyDropNewest drops the new event.
+	FullPolicyDropNewest FullPolicy = "DropNewest"
+)
+
 // EventStoreOptions defines options to initialize the machine event store
 type EventStoreOptions struct {
 	MaxEvents      int
 	TTL            time.Duration
 	ResyncInterval time.Duration
+	// TTLDisabled keeps events until they are evicted by MaxEvents, ignoring TTL.
+	TTLDisabled bool
+	// ShrinkAfter is the duration the store occupancy has to stay below a quarter of the allocated
+	// capacity before the backing array is shrunk. Zero disables shrinking.
+	ShrinkAfter time.Duration
+	// KnownReasons are the reasons accepted by ValidatedEventf, additional ones can be added via RegisterReasons.
+	KnownReasons []string
+	// FullPolicy defines how new events are handled once MaxEvents is reached. Defaults to FullPolicyOverwriteOldest.
+	FullPolicy FullPolicy
+	// Clock is used for event times and TTL expiry. Defaults to the real clock.
+	Clock clock.PassiveClock
 }
 
 func (o *EventStoreOptions) Defaults() {
@@ -51,19 +85,33 @@ func (o *EventStoreOptions) Defaults() {
 	if o.ResyncInterval <= 0 {
 		o.ResyncInterval = time.Minute
 	}
+
+	if o.FullPolicy == "" {
+		o.FullPolicy = FullPolicyOverwriteOldest
+	}
+
+	if o.Clock == nil {
+		o.Clock = clock.RealClock{}
+	}
 }
 
 // Store implements the EventRecorder and EventStore interface
 // and represents an in-memory event store with TTL for events.
 type Store struct {
-	maxEvents           int           // Maximum number of events in the store
-	events              []*Event      // Slice of events
-	mutex               sync.Mutex    // Mutex for thread safety
-	eventTTL            time.Duration // TTL for events
-	eventResyncInterval time.Duration // Resync interval for event store's TTL expiration check
-	head                int           // Index of the oldest event
-	count               int           // Current number of events in the store
-	log                 logr.Logger   // Logger for logging overridden events
+	maxEvents           int              // Maximum number of events in the store
+	events              []*Event         // Slice of events
+	mutex               sync.Mutex       // Mutex for thread safety
+	eventTTL            time.Duration    // TTL for events
+	ttlDisabled         bool             // Whether events never expire by TTL
+	eventResyncInterval time.Duration    // Resync interval for event store's TTL expiration check
+	head                int              // Index of the oldest event
+	count               int              // Current number of events in the store
+	log                 logr.Logger      // Logger for logging overridden events
+	knownReasons        sets.Set[string] // Reasons accepted by ValidatedEventf
+	shrinkAfter         time.Duration    // Duration of low occupancy after which the backing array is shrunk
+	lowOccupancySince   time.Time        // Time since which occupancy is below the shrink threshold
+	fullPolicy          FullPolicy       // Handling of new events in a full store
+	clock               clock.PassiveClock
 }
 
 // NewEventStore creates a new EventStore with a fixed number of events and set TTL for events.
@@ -74,71 +122,182 @@ func NewEventStore(log logr.Logger, opts EventStoreOptions) *Store {
 		maxEvents:           opts.MaxEvents,
 		events:              make([]*Event, opts.MaxEvents),
 		eventTTL:            opts.TTL,
+		ttlDisabled:         opts.TTLDisabled,
 		eventResyncInterval: opts.ResyncInterval,
 		head:                0,
 		count:               0,
 		log:                 log,
+		knownReasons:        sets.New(opts.KnownReasons...),
+		shrinkAfter:         opts.ShrinkAfter,
+		fullPolicy:          opts.FullPolicy,
+		clock:               opts.Clock,
 	}
 }
 
 // Eventf logs and records an event with formatted message.
+// Events with an empty metadata ID are recorded in the node scope.
 func (es *Store) Eventf(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) {
-	es.recordEvent(apiMetadata, eventType, reason, fmt.Sprintf(messageFormat, args...))
+	es.recordEvent(apiMetadata, eventType, reason, fmt.Sprintf(messageFormat, args...), nil)
+}
+
+// TryEventf records an event like Eventf and reports whether it got stored.
+func (es *Store) TryEventf(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) bool {
+	return es.recordEvent(apiMetadata, eventType, reason, fmt.Sprintf(messageFormat, args...), nil) != nil
 }
 
-// recordEvent adds a new Event to the store. Implements the EventRecorder interface.
-func (es *Store) recordEvent(metadata api.Metadata, eventType, reason, message string) {
+// RecordEvent records an event like Eventf and returns a copy of the stored event, nil if it got dropped.
+func (es *Store) RecordEvent(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) *Event {
+	return es.recordEvent(apiMetadata, eventType, reason, fmt.Sprintf(messageFormat, args...), nil)
+}
+
+// NodeEventf records an event with formatted message in the node scope.
+func (es *Store) NodeEventf(eventType, reason, messageFormat string, args ...any) {
+	es.recordEvent(api.Metadata{}, eventType, reason, fmt.Sprintf(messageFormat, args...), nil)
+}
+
+// recordEvent adds a new Event to the store and returns a copy of it, nil if it got dropped.
+// Implements the EventRecorder interface.
+func (es *Store) recordEvent(metadata api.Metadata, eventType, reason, message string, fields map[string]string) *Event {
 	es.mutex.Lock()
 	defer es.mutex.Unlock()
 
+	if es.count == es.maxEvents && es.fullPolicy == FullPolicyDropNewest {
+		es.log.V(1).Info("Dropping event, store is full", "reason", reason)
+		return nil
+	}
+
+	// Grow a shrunk backing array back on demand
+	if es.count == len(es.events) && len(es.events) < es.maxEvents {
+		es.resize(min(2*len(es.events), es.maxEvents))
+	}
+
 	// Calculate the index where the new event will be inserted
-	index := (es.head + es.count) % es.maxEvents
+	index := (es.head + es.count) % len(es.events)
 
 	// If the store is full, log and overwrite the oldest event and move the head
 	if es.count == es.maxEvents {
 		es.log.V(1).Info("Overriding event", "event", es.events[es.head])
-		es.head = (es.head + 1) % es.maxEvents
+		es.head = (es.head + 1) % len(es.events)
 	} else {
 		es.count++
 	}
 
+	if metadata.ID == "" {
+		metadata.ID = NodeScopeID
+	}
+
 	event := &Event{
 		InvolvedObjectMeta: metadata,
 		Type:               eventType,
 		Reason:             reason,
 		Message:            message,
-		EventTime:          time.Now().Unix(),
+		EventTime:          es.clock.Now().Unix(),
+		Fields:             maps.Clone(fields),
 	}
 
 	es.events[index] = event
+	return copyEvent(event)
 }
 
 // removeExpiredEvents checks and removes events whose TTL has expired.
 func (es *Store) removeExpiredEvents() {
+	if es.ttlDisabled {
+		return
+	}
+
 	es.mutex.Lock()
 	defer es.mutex.Unlock()
 
-	now := time.Now()
+	now := es.clock.Now()
 
 	for es.count > 0 {
-		index := es.head % es.maxEvents
-		event := es.events[index]
-		eventTime := time.Unix(event.EventTime, 0)
-		eventTimeWithDuration := eventTime.Add(es.eventTTL)
-
-		if eventTimeWithDuration.After(now) {
+		index := es.head % len(es.events)
+		if !es.expired(es.events[index], now) {
 			break
 		}
 
 		// Clear the reference to the expired event
 		es.events[index] = nil
-		es.head = (es.head + 1) % es.maxEvents
+		es.head = (es.head + 1) % len(es.events)
 		es.count--
 	}
+
+	es.maybeShrink(now)
+}
+
+// expired reports whether the TTL of the event has expired at the given time.
+func (es *Store) expired(event *Event, now time.Time) bool {
+	if es.ttlDisabled {
+		return false
+	}
+	return !time.Unix(event.EventTime, 0).Add(es.eventTTL).After(now)
+}
+
+// ListExpired returns a copy of all events whose TTL has expired but that were not removed yet.
+func (es *Store) ListExpired() []*Event {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	now := es.clock.Now()
+
+	var result []*Event
+	for i := 0; i < es.count; i++ {
+		event := es.events[(es.head+i)%len(es.events)]
+		if es.expired(event, now) {
+			result = append(result, copyEvent(event))
+		}
+	}
+
+	return result
+}
+
+// maybeShrink reallocates a smaller backing array once occupancy stayed below a quarter
+// of the capacity for the configured duration.
+func (es *Store) maybeShrink(now time.Time) {
+	if es.shrinkAfter <= 0 || len(es.events) <= minEventsCapacity || es.count >= len(es.events)/4 {
+		es.lowOccupancySince = time.Time{}
+		return
+	}
+
+	if es.lowOccupancySince.IsZero() {
+		es.lowOccupancySince = now
+		return
+	}
+
+	if now.Sub(es.lowOccupancySince) < es.shrinkAfter {
+		return
+	}
+
+	es.resize(max(2*es.count, minEventsCapacity))
+	es.lowOccupancySince = time.Time{}
+}
+
+// resize moves the events into a new backing array of the given capacity, starting at index 0.
+func (es *Store) resize(capacity int) {
+	events := make([]*Event, capacity)
+	for i := 0; i < es.count; i++ {
+		events[i] = es.events[(es.head+i)%len(es.events)]
+	}
+
+	es.events = events
+	es.head = 0
+}
+
+// Capacity returns the number of events the currently allocated backing array can hold.
+func (es *Store) Capacity() int {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	return len(es.events)
 }
 
 // Start initializes and starts the event store's TTL expiration check.
+// It returns immediately if TTLDisabled is set.
 func (es *Store) Start(ctx context.Context) {
+	if es.ttlDisabled {
+		return
+	}
+
 	wait.UntilWithContext(ctx, func(ctx context.Context) {
 		es.removeExpiredEvents()
 	}, es.eventResyncInterval)
@@ -151,16 +310,75 @@ func (es *Store) ListEvents() []*Event {
 
 	result := make([]*Event, 0, es.count)
 	for i := 0; i < es.count; i++ {
-		index := (es.head + i) % es.maxEvents
-		event := es.events[index]
-		result = append(result, &Event{
-			InvolvedObjectMeta: event.InvolvedObjectMeta,
-			Type:               event.Type,
-			Reason:             event.Reason,
-			Message:            event.Message,
-			EventTime:          event.EventTime,
-		})
+		index := (es.head + i) % len(es.events)
+		result = append(result, copyEvent(es.events[index]))
+	}
+
+	return result
+}
+
+func copyEvent(event *Event) *Event {
+	return &Event{
+		InvolvedObjectMeta: event.InvolvedObjectMeta,
+		Type:               event.Type,
+		Reason:             event.Reason,
+		Message:            event.Message,
+		EventTime:          event.EventTime,
+		Fields:             maps.Clone(event.Fields),
+	}
+}
+
+// ListEventsForObject returns a copy of all events currently in the store involving the object with the given id.
+// Use NodeScopeID to list events that don't involve a specific object.
+func (es *Store) ListEventsForObject(id string) []*Event {
+	var result []*Event
+	for _, event := range es.ListEvents() {
+		if event.InvolvedObjectMeta.ID == id {
+			result = append(result, event)
+		}
 	}
 
 	return result
 }
+
+// WriteEvents streams all events currently in the store as a JSON array to w. Only the event
+// pointers are snapshotted under the lock, so a slow writer does not block recording.
+func (es *Store) WriteEvents(w io.Writer) error {
+	return writeEvents(w, es.snapshotEvents())
+}
+
+// snapshotEvents returns the pointers of the events currently in the store, see WriteEvents.
+func (es *Store) snapshotEvents() []*Event {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	events := make([]*Event, 0, es.count)
+	for i := 0; i < es.count; i++ {
+		events = append(events, es.events[(es.head+i)%len(es.events)])
+	}
+	return events
+}
+
+// writeEvents streams the events as a JSON array to w.
+func writeEvents(w io.Writer, events []*Event) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i, event := range events {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
@@ -4,7 +4,9 @@
 package recorder_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -16,6 +18,10 @@ import (
 	"github.com/ironcore-dev/provider-utils/eventutils/recorder"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
 )
 
 func TestHandler(t *testing.T) {
@@ -125,6 +131,59 @@ var _ = Describe("Machine EventStore", func() {
 		})
 	})
 
+	Context("ListExpired", func() {
+		It("should list expired events without removing them", func() {
+			fakeClock := testingclock.NewFakePassiveClock(time.Now())
+			clockOpts := opts
+			clockOpts.Clock = fakeClock
+			expiringStore := recorder.NewEventStore(log, clockOpts)
+
+			expiringStore.Eventf(apiMetadata, eventType, reason, "old")
+			Expect(expiringStore.ListExpired()).To(BeEmpty())
+
+			fakeClock.SetTime(fakeClock.Now().Add(eventTTL))
+			expiringStore.Eventf(apiMetadata, eventType, reason, "new")
+
+			By("advancing the clock past the ttl of the first event")
+			fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+
+			expired := expiringStore.ListExpired()
+			Expect(expired).To(HaveLen(1))
+			Expect(expired[0].Message).To(Equal("old"))
+			Expect(expiringStore.ListEvents()).To(HaveLen(2))
+		})
+	})
+
+	Context("TTLDisabled", func() {
+		It("should keep events until the store is full", func() {
+			fakeClock := testingclock.NewFakePassiveClock(time.Now())
+			disabledOpts := opts
+			disabledOpts.Clock = fakeClock
+			disabledOpts.TTLDisabled = true
+			disabledStore := recorder.NewEventStore(log, disabledOpts)
+
+			for i := range maxEvents {
+				disabledStore.Eventf(apiMetadata, eventType, reason, "event-%d", i)
+			}
+
+			By("advancing the clock far past the ttl")
+			fakeClock.SetTime(fakeClock.Now().Add(100 * eventTTL))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			disabledStore.Start(ctx)
+
+			Expect(disabledStore.ListExpired()).To(BeEmpty())
+			Expect(disabledStore.ListEvents()).To(HaveLen(maxEvents))
+
+			By("evicting the oldest event once the store is full")
+			disabledStore.Eventf(apiMetadata, eventType, reason, "newest")
+			events := disabledStore.ListEvents()
+			Expect(events).To(HaveLen(maxEvents))
+			Expect(events[0].Message).To(Equal("event-1"))
+		})
+	})
+
 	Context("Start", func() {
 		It("should periodically remove expired events", func() {
 			ctx, cancel := context.WithCancel(context.Background())
@@ -142,6 +201,154 @@ var _ = Describe("Machine EventStore", func() {
 		})
 	})
 
+	Context("TryEventf", func() {
+		It("should report whether the event got stored", func() {
+			By("overwriting the oldest event by default")
+			for i := 0; i < maxEvents; i++ {
+				Expect(es.TryEventf(apiMetadata, eventType, reason, message)).To(BeTrue())
+			}
+			Expect(es.TryEventf(apiMetadata, eventType, reason, message)).To(BeTrue())
+
+			By("dropping new events of a full store under DropNewest")
+			dropOpts := opts
+			dropOpts.FullPolicy = recorder.FullPolicyDropNewest
+			dropping := recorder.NewEventStore(log, dropOpts)
+			for i := 0; i < maxEvents; i++ {
+				Expect(dropping.TryEventf(apiMetadata, eventType, reason, "%d", i)).To(BeTrue())
+			}
+			Expect(dropping.TryEventf(apiMetadata, eventType, reason, "dropped")).To(BeFalse())
+
+			events := dropping.ListEvents()
+			Expect(events).To(HaveLen(maxEvents))
+			Expect(events[maxEvents-1].Message).To(Equal(fmt.Sprint(maxEvents - 1)))
+		})
+	})
+
+	Context("RecordEvent", func() {
+		It("should return the stored event", func() {
+			event := es.RecordEvent(apiMetadata, eventType, reason, "%s %d", message, 1)
+			Expect(event).NotTo(BeNil())
+			Expect(event.Message).To(Equal(message + " 1"))
+
+			events := es.ListEvents()
+			Expect(events).To(HaveLen(1))
+			Expect(event).To(Equal(events[0]))
+
+			By("not affecting the store when mutating the returned event")
+			event.Message = "mutated"
+			Expect(es.ListEvents()[0].Message).To(Equal(message + " 1"))
+
+			By("returning nil for dropped events")
+			dropOpts := opts
+			dropOpts.FullPolicy = recorder.FullPolicyDropNewest
+			dropping := recorder.NewEventStore(log, dropOpts)
+			for i := 0; i < maxEvents; i++ {
+				Expect(dropping.RecordEvent(apiMetadata, eventType, reason, message)).NotTo(BeNil())
+			}
+			Expect(dropping.RecordEvent(apiMetadata, eventType, reason, message)).To(BeNil())
+		})
+	})
+
+	Context("Shrink", func() {
+		It("should shrink the backing array on low occupancy and grow it back on demand", func(ctx SpecContext) {
+			const capacity = 64
+			shrinkingStore := recorder.NewEventStore(log, recorder.EventStoreOptions{
+				MaxEvents:      capacity,
+				TTL:            time.Second,
+				ResyncInterval: 100 * time.Millisecond,
+				ShrinkAfter:    200 * time.Millisecond,
+			})
+			Expect(shrinkingStore.Capacity()).To(Equal(capacity))
+
+			for i := 0; i < capacity; i++ {
+				shrinkingStore.Eventf(apiMetadata, eventType, reason, "%d", i)
+			}
+
+			innerCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go shrinkingStore.Start(innerCtx)
+
+			By("driving occupancy down by expiring all events")
+			Eventually(shrinkingStore.ListEvents).WithTimeout(5 * time.Second).Should(BeEmpty())
+			Eventually(shrinkingStore.Capacity).WithTimeout(5 * time.Second).Should(BeNumerically("<", capacity))
+			cancel()
+
+			By("growing back on demand")
+			for i := 0; i < capacity; i++ {
+				shrinkingStore.Eventf(apiMetadata, eventType, reason, "%d", i)
+			}
+			Expect(shrinkingStore.Capacity()).To(Equal(capacity))
+
+			events := shrinkingStore.ListEvents()
+			Expect(events).To(HaveLen(capacity))
+			for i, event := range events {
+				Expect(event.Message).To(Equal(fmt.Sprint(i)))
+			}
+		})
+	})
+
+	Context("ValidatedEventf", func() {
+		It("should reject invalid event types and unknown reasons", func() {
+			es.RegisterReasons(reason)
+
+			err := es.ValidatedEventf(apiMetadata, "Normla", reason, message)
+			Expect(err).To(MatchError(recorder.ErrInvalidEventType))
+
+			err = es.ValidatedEventf(apiMetadata, recorder.EventTypeNormal, "Unregistered", message)
+			Expect(err).To(MatchError(recorder.ErrUnknownReason))
+			Expect(es.ListEvents()).To(BeEmpty())
+
+			Expect(es.ValidatedEventf(apiMetadata, recorder.EventTypeWarning, reason, message)).To(Succeed())
+			Expect(es.ListEvents()).To(HaveLen(1))
+		})
+	})
+
+	Context("RuntimeRecorder", func() {
+		It("should record events of runtime objects into the store", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pod",
+					UID:         types.UID("pod-uid"),
+					Labels:      map[string]string{"app": "test"},
+					Annotations: map[string]string{"existing": "true"},
+				},
+			}
+
+			runtimeRecorder := recorder.NewRuntimeRecorder(log, es)
+			runtimeRecorder.Event(pod, recorder.EventTypeNormal, reason, message)
+			runtimeRecorder.AnnotatedEventf(pod, map[string]string{"added": "true"},
+				recorder.EventTypeWarning, reason, "%s %d", message, 2)
+
+			events := es.ListEventsForObject("pod-uid")
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Type).To(Equal(recorder.EventTypeNormal))
+			Expect(events[0].Message).To(Equal(message))
+			Expect(events[0].InvolvedObjectMeta.Labels).To(Equal(map[string]string{"app": "test"}))
+			Expect(events[1].Message).To(Equal(message + " 2"))
+			Expect(events[1].InvolvedObjectMeta.Annotations).To(Equal(map[string]string{
+				"existing": "true",
+				"added":    "true",
+			}))
+			Expect(pod.Annotations).To(HaveLen(1))
+		})
+	})
+
+	Context("NodeEvents", func() {
+		It("should record events with empty metadata in the node scope", func() {
+			es.Eventf(api.Metadata{}, eventType, reason, "PCI rescan completed")
+			es.NodeEventf(eventType, reason, "%s %d", message, 1)
+			es.Eventf(apiMetadata, eventType, reason, message)
+
+			nodeEvents := es.ListEventsForObject(recorder.NodeScopeID)
+			Expect(nodeEvents).To(HaveLen(2))
+			Expect(nodeEvents[0].Message).To(Equal("PCI rescan completed"))
+			Expect(nodeEvents[1].Message).To(Equal(message + " 1"))
+
+			Expect(es.ListEventsForObject(apiMetadata.ID)).To(HaveLen(1))
+			Expect(es.ListEvents()).To(HaveLen(3))
+		})
+	})
+
 	Context("ListEvents", func() {
 		It("should return all current events", func() {
 			es.Eventf(apiMetadata, eventType, reason, message)
@@ -164,4 +371,77 @@ var _ = Describe("Machine EventStore", func() {
 			Expect(storedEvents[0].Message).ToNot(Equal(events[0].Message))
 		})
 	})
+
+	Context("WriteEvents", func() {
+		It("should stream the current events as a JSON array", func() {
+			var buf bytes.Buffer
+			Expect(es.WriteEvents(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal("[]"))
+
+			for i := 0; i < maxEvents+1; i++ {
+				es.Eventf(apiMetadata, eventType, reason, "%s %d", message, i)
+			}
+
+			buf.Reset()
+			Expect(es.WriteEvents(&buf)).To(Succeed())
+
+			var written []*recorder.Event
+			Expect(json.Unmarshal(buf.Bytes(), &written)).To(Succeed())
+			Expect(written).To(Equal(es.ListEvents()))
+		})
+	})
+
+	Context("ListEventsMasked", func() {
+		It("should blank the masked fields and preserve the others", func() {
+			labeledMetadata := apiMetadata
+			labeledMetadata.Labels = map[string]string{"key": "value"}
+			es.Eventf(labeledMetadata, eventType, reason, message)
+
+			events := es.ListEventsMasked(recorder.EventFieldAnnotations)
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].InvolvedObjectMeta.Annotations).To(BeEmpty())
+			Expect(events[0].InvolvedObjectMeta.Labels).To(Equal(labeledMetadata.Labels))
+			Expect(events[0].InvolvedObjectMeta.ID).To(Equal(apiMetadata.ID))
+			Expect(events[0].Type).To(Equal(eventType))
+			Expect(events[0].Reason).To(Equal(reason))
+			Expect(events[0].Message).To(Equal(message))
+
+			By("masking several fields")
+			events = es.ListEventsMasked(recorder.EventFieldLabels | recorder.EventFieldMessage)
+			Expect(events[0].InvolvedObjectMeta.Annotations).To(Equal(apiMetadata.Annotations))
+			Expect(events[0].InvolvedObjectMeta.Labels).To(BeEmpty())
+			Expect(events[0].Message).To(BeEmpty())
+
+			By("leaving the stored events untouched")
+			Expect(es.ListEvents()[0].InvolvedObjectMeta.Annotations).To(Equal(apiMetadata.Annotations))
+		})
+	})
+
+	Context("ListEventsMatching", func() {
+		It("should filter events by their fields", func() {
+			fields := map[string]string{"volume": "pvc-123", "phase": "attach"}
+			es.EventfWithFields(apiMetadata, eventType, reason, fields, "attaching %s", "pvc-123")
+			es.EventfWithFields(apiMetadata, eventType, reason, map[string]string{"volume": "pvc-123", "phase": "detach"}, "detaching")
+			es.EventfWithFields(apiMetadata, eventType, reason, map[string]string{"volume": "pvc-456", "phase": "attach"}, "attaching")
+			es.Eventf(apiMetadata, eventType, reason, message)
+
+			By("matching a single field")
+			Expect(es.ListEventsMatching(map[string]string{"volume": "pvc-123"})).To(HaveLen(2))
+
+			By("matching all given fields")
+			events := es.ListEventsMatching(map[string]string{"volume": "pvc-123", "phase": "attach"})
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Message).To(Equal("attaching pvc-123"))
+			Expect(events[0].Fields).To(Equal(fields))
+
+			By("matching all events without fields to match")
+			Expect(es.ListEventsMatching(nil)).To(HaveLen(4))
+			Expect(es.ListEventsMatching(map[string]string{"volume": "pvc-789"})).To(BeEmpty())
+
+			By("copying the fields of recorded and listed events")
+			fields["phase"] = "changed"
+			events[0].Fields["phase"] = "changed"
+			Expect(es.ListEventsMatching(map[string]string{"phase": "attach"})).To(HaveLen(2))
+		})
+	})
 })
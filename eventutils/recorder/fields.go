@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder
+
+import (
+	"fmt"
+
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+)
+
+// EventfWithFields records an event like Eventf with the given structured fields.
+func (es *Store) EventfWithFields(
+	apiMetadata api.Metadata,
+	eventType, reason string,
+	fields map[string]string,
+	messageFormat string,
+	args ...any,
+) {
+	es.recordEvent(apiMetadata, eventType, reason, fmt.Sprintf(messageFormat, args...), fields)
+}
+
+// ListEventsMatching returns a copy of all events currently in the store having all the given fields.
+func (es *Store) ListEventsMatching(fields map[string]string) []*Event {
+	var result []*Event
+	for _, event := range es.ListEvents() {
+		if hasFields(event, fields) {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
+
+func hasFields(event *Event, fields map[string]string) bool {
+	for key, value := range fields {
+		if actual, ok := event.Fields[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// EventfWithFields records an event like Eventf with the given structured fields in the shard of the involved object.
+func (s *ShardedStore) EventfWithFields(
+	apiMetadata api.Metadata,
+	eventType, reason string,
+	fields map[string]string,
+	messageFormat string,
+	args ...any,
+) {
+	s.shardFor(apiMetadata.ID).EventfWithFields(apiMetadata, eventType, reason, fields, messageFormat, args...)
+}
+
+// ListEventsMatching returns a copy of all events of all shards having all the given fields, ordered by event time.
+func (s *ShardedStore) ListEventsMatching(fields map[string]string) []*Event {
+	var result []*Event
+	for _, event := range s.ListEvents() {
+		if hasFields(event, fields) {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
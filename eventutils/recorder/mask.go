@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder
+
+// EventFieldMask selects event fields to blank in listed copies.
+type EventFieldMask uint8
+
+const (
+	// EventFieldAnnotations blanks the annotations of the involved object.
+	EventFieldAnnotations EventFieldMask = 1 << iota
+	// EventFieldLabels blanks the labels of the involved object.
+	EventFieldLabels
+	// EventFieldMessage blanks the event message.
+	EventFieldMessage
+)
+
+// Has reports whether the mask contains all fields of field.
+func (m EventFieldMask) Has(field EventFieldMask) bool {
+	return m&field == field
+}
+
+// ListEventsMasked returns a copy of all events currently in the store with the fields of the mask blanked.
+func (es *Store) ListEventsMasked(mask EventFieldMask) []*Event {
+	return maskEvents(es.ListEvents(), mask)
+}
+
+// ListEventsMasked returns a copy of all events of all shards, ordered by event time, with the fields of
+// the mask blanked.
+func (s *ShardedStore) ListEventsMasked(mask EventFieldMask) []*Event {
+	return maskEvents(s.ListEvents(), mask)
+}
+
+// maskEvents blanks the fields of the mask in the given events.
+func maskEvents(events []*Event, mask EventFieldMask) []*Event {
+	for _, event := range events {
+		if mask.Has(EventFieldAnnotations) {
+			event.InvolvedObjectMeta.Annotations = nil
+		}
+		if mask.Has(EventFieldLabels) {
+			event.InvolvedObjectMeta.Labels = nil
+		}
+		if mask.Has(EventFieldMessage) {
+			event.Message = ""
+		}
+	}
+
+	return events
+}
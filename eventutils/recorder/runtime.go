@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder
+
+import (
+	"maps"
+
+	"github.com/go-logr/logr"
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// NewRuntimeRecorder returns a record.EventRecorder recording events of runtime objects into the given recorder.
+// The involved object metadata is taken from the object accessor, using the object uid as id.
+// Events of objects without accessor are recorded in the node scope.
+func NewRuntimeRecorder(log logr.Logger, recorder EventRecorder) record.EventRecorder {
+	return &runtimeRecorder{
+		log:      log,
+		recorder: recorder,
+	}
+}
+
+type runtimeRecorder struct {
+	log      logr.Logger
+	recorder EventRecorder
+}
+
+func (r *runtimeRecorder) Event(object runtime.Object, eventType, reason, message string) {
+	r.recorder.Eventf(r.metadata(object, nil), eventType, reason, "%s", message)
+}
+
+func (r *runtimeRecorder) Eventf(object runtime.Object, eventType, reason, messageFormat string, args ...any) {
+	r.recorder.Eventf(r.metadata(object, nil), eventType, reason, messageFormat, args...)
+}
+
+func (r *runtimeRecorder) AnnotatedEventf(
+	object runtime.Object,
+	annotations map[string]string,
+	eventType, reason, messageFormat string,
+	args ...any,
+) {
+	r.recorder.Eventf(r.metadata(object, annotations), eventType, reason, messageFormat, args...)
+}
+
+func (r *runtimeRecorder) metadata(object runtime.Object, annotations map[string]string) api.Metadata {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		r.log.V(1).Info("Recording event of object without accessor in node scope", "error", err)
+		return api.Metadata{Annotations: annotations}
+	}
+
+	objAnnotations := maps.Clone(accessor.GetAnnotations())
+	if len(annotations) > 0 {
+		if objAnnotations == nil {
+			objAnnotations = map[string]string{}
+		}
+		maps.Copy(objAnnotations, annotations)
+	}
+
+	return api.Metadata{
+		ID:          string(accessor.GetUID()),
+		Annotations: objAnnotations,
+		Labels:      maps.Clone(accessor.GetLabels()),
+		CreatedAt:   accessor.GetCreationTimestamp().Time,
+	}
+}
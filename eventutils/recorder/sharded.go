@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder
+
+import (
+	"cmp"
+	"context"
+	"hash/fnv"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+)
+
+// ShardedEventStoreOptions defines options to initialize the sharded event store.
+type ShardedEventStoreOptions struct {
+	EventStoreOptions
+	// Shards is the number of sub-stores. Defaults to 8.
+	Shards int
+}
+
+func (o *ShardedEventStoreOptions) Defaults() {
+	o.EventStoreOptions.Defaults()
+
+	if o.Shards <= 0 {
+		o.Shards = 8
+	}
+}
+
+// ShardedStore partitions events by the id of the involved object into sub-stores with their own
+// lock and ring buffer, reducing contention under heavy load. MaxEvents is split evenly across the
+// shards, so a single busy object may lose events earlier than with a Store of the same size. It offers
+// the methods of Store, listing the events of all shards ordered by event time.
+type ShardedStore struct {
+	shards []*Store
+}
+
+// NewShardedEventStore creates a new ShardedStore.
+func NewShardedEventStore(log logr.Logger, opts ShardedEventStoreOptions) *ShardedStore {
+	opts.Defaults()
+
+	shardOpts := opts.EventStoreOptions
+	shardOpts.MaxEvents = (opts.MaxEvents + opts.Shards - 1) / opts.Shards
+
+	shards := make([]*Store, 0, opts.Shards)
+	for i := 0; i < opts.Shards; i++ {
+		shards = append(shards, NewEventStore(log.WithValues("shard", i), shardOpts))
+	}
+
+	return &ShardedStore{
+		shards: shards,
+	}
+}
+
+func (s *ShardedStore) shardFor(id string) *Store {
+	if id == "" {
+		id = NodeScopeID
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// Eventf records an event with formatted message in the shard of the involved object.
+func (s *ShardedStore) Eventf(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) {
+	s.shardFor(apiMetadata.ID).Eventf(apiMetadata, eventType, reason, messageFormat, args...)
+}
+
+// TryEventf records an event like Eventf and reports whether it got stored.
+func (s *ShardedStore) TryEventf(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) bool {
+	return s.shardFor(apiMetadata.ID).TryEventf(apiMetadata, eventType, reason, messageFormat, args...)
+}
+
+// RecordEvent records an event like Eventf and returns a copy of the stored event, nil if it got dropped.
+func (s *ShardedStore) RecordEvent(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) *Event {
+	return s.shardFor(apiMetadata.ID).RecordEvent(apiMetadata, eventType, reason, messageFormat, args...)
+}
+
+// NodeEventf records an event with formatted message in the node scope.
+func (s *ShardedStore) NodeEventf(eventType, reason, messageFormat string, args ...any) {
+	s.shardFor(NodeScopeID).NodeEventf(eventType, reason, messageFormat, args...)
+}
+
+// ListEvents returns a copy of all events of all shards, ordered by event time.
+func (s *ShardedStore) ListEvents() []*Event {
+	var result []*Event
+	for _, shard := range s.shards {
+		result = append(result, shard.ListEvents()...)
+	}
+
+	sortByEventTime(result)
+	return result
+}
+
+// ListExpired returns a copy of all events of all shards whose TTL has expired but that were not removed
+// yet, ordered by event time.
+func (s *ShardedStore) ListExpired() []*Event {
+	var result []*Event
+	for _, shard := range s.shards {
+		result = append(result, shard.ListExpired()...)
+	}
+
+	sortByEventTime(result)
+	return result
+}
+
+// WriteEvents streams all events of all shards as a JSON array ordered by event time to w, like
+// Store.WriteEvents.
+func (s *ShardedStore) WriteEvents(w io.Writer) error {
+	var events []*Event
+	for _, shard := range s.shards {
+		events = append(events, shard.snapshotEvents()...)
+	}
+
+	sortByEventTime(events)
+	return writeEvents(w, events)
+}
+
+// sortByEventTime sorts events by event time, keeping the order of events recorded at the same time.
+func sortByEventTime(events []*Event) {
+	slices.SortStableFunc(events, func(a, b *Event) int {
+		return cmp.Compare(a.EventTime, b.EventTime)
+	})
+}
+
+// ListEventsForObject returns a copy of all events involving the object with the given id.
+func (s *ShardedStore) ListEventsForObject(id string) []*Event {
+	return s.shardFor(id).ListEventsForObject(id)
+}
+
+// Capacity returns the number of events the currently allocated backing arrays of all shards can hold.
+func (s *ShardedStore) Capacity() int {
+	var capacity int
+	for _, shard := range s.shards {
+		capacity += shard.Capacity()
+	}
+	return capacity
+}
+
+// Start starts the TTL expiration check of all shards and blocks until ctx is done.
+func (s *ShardedStore) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, shard := range s.shards {
+		wg.Go(func() {
+			shard.Start(ctx)
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/eventutils/recorder"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+// eventStore is the method set shared by Store and ShardedStore.
+type eventStore interface {
+	recorder.EventRecorder
+	recorder.EventStore
+	TryEventf(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) bool
+	RecordEvent(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) *recorder.Event
+	NodeEventf(eventType, reason, messageFormat string, args ...any)
+	EventfWithFields(apiMetadata api.Metadata, eventType, reason string, fields map[string]string, messageFormat string, args ...any)
+	ValidatedEventf(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) error
+	RegisterReasons(reasons ...string)
+	ListEventsForObject(id string) []*recorder.Event
+	ListEventsMatching(fields map[string]string) []*recorder.Event
+	ListEventsMasked(mask recorder.EventFieldMask) []*recorder.Event
+	ListExpired() []*recorder.Event
+	WriteEvents(w io.Writer) error
+	Capacity() int
+}
+
+var (
+	_ eventStore = &recorder.Store{}
+	_ eventStore = &recorder.ShardedStore{}
+)
+
+var _ = Describe("Sharded EventStore", func() {
+	It("should list events across all shards", func() {
+		sharded := recorder.NewShardedEventStore(log, recorder.ShardedEventStoreOptions{
+			EventStoreOptions: recorder.EventStoreOptions{
+				MaxEvents: 100,
+				TTL:       eventTTL,
+			},
+			Shards: 4,
+		})
+		var _ recorder.EventRecorder = sharded
+		var _ recorder.EventStore = sharded
+
+		const objects = 16
+		for i := 0; i < objects; i++ {
+			sharded.Eventf(api.Metadata{ID: fmt.Sprintf("object-%d", i)}, eventType, reason, "%d", i)
+		}
+		sharded.NodeEventf(eventType, reason, "node")
+
+		events := sharded.ListEvents()
+		Expect(events).To(HaveLen(objects + 1))
+
+		var messages []string
+		for _, event := range events {
+			messages = append(messages, event.Message)
+		}
+		for i := 0; i < objects; i++ {
+			Expect(messages).To(ContainElement(fmt.Sprint(i)))
+		}
+
+		By("listing the events of a single object")
+		objectEvents := sharded.ListEventsForObject("object-3")
+		Expect(objectEvents).To(HaveLen(1))
+		Expect(objectEvents[0].Message).To(Equal("3"))
+
+		nodeEvents := sharded.ListEventsForObject(recorder.NodeScopeID)
+		Expect(nodeEvents).To(HaveLen(1))
+		Expect(nodeEvents[0].Message).To(Equal("node"))
+	})
+
+	It("should write, mask and expire events across all shards", func() {
+		fakeClock := testingclock.NewFakePassiveClock(time.Now())
+		sharded := recorder.NewShardedEventStore(log, recorder.ShardedEventStoreOptions{
+			EventStoreOptions: recorder.EventStoreOptions{
+				MaxEvents: 100,
+				TTL:       eventTTL,
+				Clock:     fakeClock,
+			},
+			Shards: 4,
+		})
+
+		const objects = 8
+		for i := 0; i < objects; i++ {
+			sharded.Eventf(api.Metadata{
+				ID:     fmt.Sprintf("object-%d", i),
+				Labels: map[string]string{"id": fmt.Sprint(i)},
+			}, eventType, reason, "%d", i)
+			fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+		}
+
+		By("writing the events of all shards ordered by event time")
+		var buf bytes.Buffer
+		Expect(sharded.WriteEvents(&buf)).To(Succeed())
+		var written []recorder.Event
+		Expect(json.Unmarshal(buf.Bytes(), &written)).To(Succeed())
+		Expect(written).To(HaveLen(objects))
+		for i, event := range written {
+			Expect(event.Message).To(Equal(fmt.Sprint(i)))
+		}
+
+		By("masking the events of all shards")
+		masked := sharded.ListEventsMasked(recorder.EventFieldLabels)
+		Expect(masked).To(HaveLen(objects))
+		Expect(masked).To(HaveEach(HaveField("InvolvedObjectMeta.Labels", BeNil())))
+
+		By("listing the expired events of all shards")
+		// the events of the first 7 seconds are at least eventTTL old
+		Expect(sharded.ListExpired()).To(HaveLen(7))
+
+		By("validating events of all shards against the registered reasons")
+		Expect(sharded.ValidatedEventf(api.Metadata{ID: "object-0"}, recorder.EventTypeNormal, "Unknown", "")).To(MatchError(recorder.ErrUnknownReason))
+		sharded.RegisterReasons("Known")
+		for i := 0; i < objects; i++ {
+			Expect(sharded.ValidatedEventf(api.Metadata{ID: fmt.Sprintf("object-%d", i)}, recorder.EventTypeNormal, "Known", "")).To(Succeed())
+		}
+	})
+})
+
+func benchmarkConcurrentEvents(b *testing.B, eventRecorder recorder.EventRecorder) {
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		metadata := api.Metadata{ID: fmt.Sprintf("object-%d", next.Add(1))}
+		for pb.Next() {
+			eventRecorder.Eventf(metadata, eventType, reason, message)
+		}
+	})
+}
+
+func BenchmarkStore_Concurrent(b *testing.B) {
+	benchmarkConcurrentEvents(b, recorder.NewEventStore(logr.Discard(), recorder.EventStoreOptions{
+		MaxEvents: 10000,
+	}))
+}
+
+func BenchmarkShardedStore_Concurrent(b *testing.B) {
+	benchmarkConcurrentEvents(b, recorder.NewShardedEventStore(logr.Discard(), recorder.ShardedEventStoreOptions{
+		EventStoreOptions: recorder.EventStoreOptions{
+			MaxEvents: 10000,
+		},
+	}))
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+)
+
+const (
+	EventTypeNormal  = "Normal"
+	EventTypeWarning = "Warning"
+)
+
+var (
+	ErrInvalidEventType = errors.New("invalid event type")
+	ErrUnknownReason    = errors.New("unknown reason")
+)
+
+// ValidateEventType returns an error if eventType is neither EventTypeNormal nor EventTypeWarning.
+func ValidateEventType(eventType string) error {
+	switch eventType {
+	case EventTypeNormal, EventTypeWarning:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidEventType, eventType)
+	}
+}
+
+// RegisterReasons adds reasons to the ones accepted by ValidatedEventf.
+func (es *Store) RegisterReasons(reasons ...string) {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	es.knownReasons.Insert(reasons...)
+}
+
+// RegisterReasons adds reasons to the ones accepted by ValidatedEventf of all shards.
+func (s *ShardedStore) RegisterReasons(reasons ...string) {
+	for _, shard := range s.shards {
+		shard.RegisterReasons(reasons...)
+	}
+}
+
+// ValidatedEventf records an event like Eventf, but rejects unknown event types and
+// reasons that have not been registered.
+func (es *Store) ValidatedEventf(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) error {
+	if err := ValidateEventType(eventType); err != nil {
+		return err
+	}
+
+	es.mutex.Lock()
+	known := es.knownReasons.Has(reason)
+	es.mutex.Unlock()
+	if !known {
+		return fmt.Errorf("%w: %q", ErrUnknownReason, reason)
+	}
+
+	es.Eventf(apiMetadata, eventType, reason, messageFormat, args...)
+	return nil
+}
+
+// ValidatedEventf records an event like ValidatedEventf of Store in the shard of the involved object.
+func (s *ShardedStore) ValidatedEventf(apiMetadata api.Metadata, eventType, reason, messageFormat string, args ...any) error {
+	return s.shardFor(apiMetadata.ID).ValidatedEventf(apiMetadata, eventType, reason, messageFormat, args...)
+}
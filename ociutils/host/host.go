@@ -4,25 +4,65 @@
 package host
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
+	"slices"
+	"strings"
 
 	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
+var (
+	ErrUnsupportedPlatform = errors.New("unsupported platform")
+	ErrInvalidPlatformRef  = errors.New("invalid platform reference")
+)
+
+// supportedOS and supportedArchitectures define the platforms images can be run on.
+const supportedOS = "linux"
+
+var supportedArchitectures = []string{"amd64", "arm64"}
+
 func Platform() (*ocispecv1.Platform, error) {
-	platform := ocispecv1.Platform{
+	architecture := runtime.GOARCH
+	if !slices.Contains(supportedArchitectures, architecture) {
+		return nil, fmt.Errorf("unsupported architecture: %s", architecture)
+	}
+
+	return &ocispecv1.Platform{
 		//TODO change if others should be supported
-		OS: "linux",
+		OS:           supportedOS,
+		Architecture: architecture,
+	}, nil
+}
+
+// ParsePlatformRef splits an image reference with an optional platform suffix, e.g. image:tag@linux/arm64,
+// into the image name and the platform. platform is nil if the reference has no suffix. A suffix containing
+// a colon is a digest, e.g. image@sha256:..., and kept as part of the name.
+func ParsePlatformRef(ref string) (name string, platform *ocispecv1.Platform, err error) {
+	i := strings.LastIndex(ref, "@")
+	if i < 0 || strings.Contains(ref[i+1:], ":") {
+		return ref, nil, nil
 	}
-	switch architecture := runtime.GOARCH; architecture {
-	case "amd64":
-		platform.Architecture = architecture
-	case "arm64":
-		platform.Architecture = architecture
-	default:
-		return nil, fmt.Errorf("unsupported architecture: %s", architecture)
+
+	name, suffix := ref[:i], ref[i+1:]
+	if name == "" {
+		return "", nil, fmt.Errorf("%w %q: missing image name", ErrInvalidPlatformRef, ref)
+	}
+
+	parts := strings.Split(suffix, "/")
+	if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+		return "", nil, fmt.Errorf("%w %q: platform must be os/arch[/variant]", ErrInvalidPlatformRef, ref)
+	}
+
+	platform = &ocispecv1.Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		platform.Variant = parts[2]
+	}
+
+	if platform.OS != supportedOS || !slices.Contains(supportedArchitectures, platform.Architecture) {
+		return "", nil, fmt.Errorf("%w %s", ErrUnsupportedPlatform, platformString(platform))
 	}
 
-	return &platform, nil
+	return name, platform, nil
 }
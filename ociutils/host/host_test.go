@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/ociutils/host"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestParsePlatformRef(t *testing.T) {
+	const digestRef = "registry.example.com/image@sha256:4d5d2ec9b1c8a9f6a2fa0a5f2e7dfd2d6b9f3c1a0e5b7c8d9e0f1a2b3c4d5e6f"
+
+	for _, tc := range []struct {
+		name         string
+		ref          string
+		wantName     string
+		wantPlatform *ocispecv1.Platform
+		wantErr      error
+	}{
+		{
+			name:     "without platform",
+			ref:      "registry.example.com/image:tag",
+			wantName: "registry.example.com/image:tag",
+		},
+		{
+			name:     "digest without platform",
+			ref:      digestRef,
+			wantName: digestRef,
+		},
+		{
+			name:         "with platform",
+			ref:          "registry.example.com/image:tag@linux/arm64",
+			wantName:     "registry.example.com/image:tag",
+			wantPlatform: &ocispecv1.Platform{OS: "linux", Architecture: "arm64"},
+		},
+		{
+			name:         "digest with platform and variant",
+			ref:          digestRef + "@linux/arm64/v8",
+			wantName:     digestRef,
+			wantPlatform: &ocispecv1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+		},
+		{
+			name:    "unsupported architecture",
+			ref:     "image:tag@linux/s390x",
+			wantErr: host.ErrUnsupportedPlatform,
+		},
+		{
+			name:    "unsupported os",
+			ref:     "image:tag@windows/amd64",
+			wantErr: host.ErrUnsupportedPlatform,
+		},
+		{
+			name:    "malformed platform",
+			ref:     "image:tag@linux",
+			wantErr: host.ErrInvalidPlatformRef,
+		},
+		{
+			name:    "missing name",
+			ref:     "@linux/amd64",
+			wantErr: host.ErrInvalidPlatformRef,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			name, platform, err := host.ParsePlatformRef(tc.ref)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name != tc.wantName {
+				t.Errorf("expected name %q, got %q", tc.wantName, name)
+			}
+			if !reflect.DeepEqual(platform, tc.wantPlatform) {
+				t.Errorf("expected platform %v, got %v", tc.wantPlatform, platform)
+			}
+		})
+	}
+}
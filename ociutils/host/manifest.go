@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"errors"
+	"fmt"
+
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var (
+	ErrNoMatchingManifest = errors.New("no manifest matching platform")
+)
+
+// Matches reports whether candidate can run on platform. OS and architecture have to be equal,
+// the variant only if both specify one.
+func Matches(platform, candidate *ocispecv1.Platform) bool {
+	if platform == nil || candidate == nil {
+		return false
+	}
+
+	if platform.OS != candidate.OS || platform.Architecture != candidate.Architecture {
+		return false
+	}
+
+	return platform.Variant == "" || candidate.Variant == "" || platform.Variant == candidate.Variant
+}
+
+// SelectManifest returns the manifest of the index best matching the platform. Manifests with
+// an equal variant are preferred over those without variant, otherwise the first match is returned.
+func SelectManifest(index ocispecv1.Index, platform *ocispecv1.Platform) (ocispecv1.Descriptor, error) {
+	var (
+		selected ocispecv1.Descriptor
+		found    bool
+	)
+	for _, manifest := range index.Manifests {
+		if !Matches(platform, manifest.Platform) {
+			continue
+		}
+
+		if platform.Variant != "" && manifest.Platform.Variant == platform.Variant {
+			return manifest, nil
+		}
+
+		if !found {
+			selected, found = manifest, true
+		}
+	}
+
+	if !found {
+		return ocispecv1.Descriptor{}, fmt.Errorf("%w %s", ErrNoMatchingManifest, platformString(platform))
+	}
+
+	return selected, nil
+}
+
+func platformString(platform *ocispecv1.Platform) string {
+	if platform == nil {
+		return "<nil>"
+	}
+
+	if platform.Variant == "" {
+		return platform.OS + "/" + platform.Architecture
+	}
+	return platform.OS + "/" + platform.Architecture + "/" + platform.Variant
+}
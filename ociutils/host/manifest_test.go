@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/ociutils/host"
+	"github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestSelectManifest(t *testing.T) {
+	descriptor := func(name, os, arch, variant string) ocispecv1.Descriptor {
+		return ocispecv1.Descriptor{
+			MediaType: ocispecv1.MediaTypeImageManifest,
+			Digest:    digest.FromString(name),
+			Platform: &ocispecv1.Platform{
+				OS:           os,
+				Architecture: arch,
+				Variant:      variant,
+			},
+		}
+	}
+
+	index := ocispecv1.Index{
+		Manifests: []ocispecv1.Descriptor{
+			{MediaType: ocispecv1.MediaTypeImageManifest, Digest: digest.FromString("no-platform")},
+			descriptor("amd64", "linux", "amd64", ""),
+			descriptor("arm64", "linux", "arm64", ""),
+			descriptor("arm64-v8", "linux", "arm64", "v8"),
+			descriptor("arm-v7", "linux", "arm", "v7"),
+		},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		platform *ocispecv1.Platform
+		want     string
+		wantErr  error
+	}{
+		{name: "amd64", platform: &ocispecv1.Platform{OS: "linux", Architecture: "amd64"}, want: "amd64"},
+		{name: "arm64 without variant", platform: &ocispecv1.Platform{OS: "linux", Architecture: "arm64"}, want: "arm64"},
+		{
+			name:     "arm64 preferring equal variant",
+			platform: &ocispecv1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+			want:     "arm64-v8",
+		},
+		{
+			name:     "arm falling through to no match on different variant",
+			platform: &ocispecv1.Platform{OS: "linux", Architecture: "arm", Variant: "v6"},
+			wantErr:  host.ErrNoMatchingManifest,
+		},
+		{
+			name:     "other os",
+			platform: &ocispecv1.Platform{OS: "windows", Architecture: "amd64"},
+			wantErr:  host.ErrNoMatchingManifest,
+		},
+		{name: "nil platform", wantErr: host.ErrNoMatchingManifest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := host.SelectManifest(index, tc.platform)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectManifest: %v", err)
+			}
+			if want := digest.FromString(tc.want); got.Digest != want {
+				t.Fatalf("expected manifest %s, got %s", tc.want, got.Digest)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ironcore-dev/provider-utils/storeutils/store"
+)
+
+// Backup writes all object files as a tar stream to w. Files are archived as stored, so encrypted
+// objects stay encrypted and can only be restored by a store with the same keys.
+func (s *Store[E]) Backup(ctx context.Context, w io.Writer) error {
+	ids, err := s.ids()
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := s.backupObject(tw, id); err != nil {
+			return fmt.Errorf("failed to back up object %s: %w", id, err)
+		}
+	}
+
+	return tw.Close()
+}
+
+func (s *Store[E]) backupObject(tw *tar.Writer, id string) error {
+	s.idMu.Lock(id)
+	defer s.idMu.Unlock(id)
+
+	data, err := os.ReadFile(filepath.Join(s.dir, id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     id,
+		Mode:     0666,
+		Size:     int64(len(data)),
+	}); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// Restore creates the objects of a tar stream written by Backup, firing a Created watch event for each.
+// Objects are decoded before being stored, restoring stops at the first object failing to decode or
+// already existing in the store.
+func (s *Store[E]) Restore(ctx context.Context, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		id := header.Name
+		if header.Typeflag != tar.TypeReg || id != filepath.Base(id) || id == "." || id == ".." {
+			return fmt.Errorf("invalid backup entry %q", id)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failed to read backup entry %s: %w", id, err)
+		}
+
+		if err := s.restoreObject(id, data); err != nil {
+			return fmt.Errorf("failed to restore object %s: %w", id, err)
+		}
+	}
+}
+
+func (s *Store[E]) restoreObject(id string, data []byte) error {
+	obj, err := s.decode(id, data)
+	if err != nil {
+		return err
+	}
+	if obj.GetID() != id {
+		return fmt.Errorf("object id %q does not match file name", obj.GetID())
+	}
+
+	s.idMu.Lock(id)
+	defer s.idMu.Unlock(id)
+
+	_, err = s.get(id)
+	switch {
+	case err == nil:
+		return fmt.Errorf("object with id %q %w", id, store.ErrAlreadyExists)
+	case errors.Is(err, store.ErrNotFound):
+	default:
+		return err
+	}
+
+	s.sequenceMu.Lock()
+	defer s.sequenceMu.Unlock()
+
+	_, err = s.write(obj, store.WatchEventTypeCreated)
+	return err
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/storeutils/utils"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+// objectCache keeps the deserialized objects of a store. Entries are valid for the store sequence they
+// got cached at: writes of the store advance the sequence along with their entry, while a sequence
+// recorded by another store on the same directory drops all entries.
+type objectCache[E api.Object] struct {
+	mu       sync.Mutex
+	sequence uint64
+	entries  map[string]E
+}
+
+func newObjectCache[E api.Object]() *objectCache[E] {
+	return &objectCache[E]{entries: map[string]E{}}
+}
+
+// get returns the cached object if no other store wrote since the recorded sequence.
+func (c *objectCache[E]) get(id string, recorded uint64) (E, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.sync(recorded) {
+		return utils.Zero[E](), false
+	}
+	obj, ok := c.entries[id]
+	return obj, ok
+}
+
+// add caches obj, read from its file at the recorded sequence.
+func (c *objectCache[E]) add(id string, obj E, recorded uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sync(recorded) {
+		c.entries[id] = obj
+	}
+}
+
+// set caches obj, written by the store at sequence.
+func (c *objectCache[E]) set(id string, obj E, sequence uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.advance(sequence)
+	c.entries[id] = obj
+}
+
+// delete drops the entry of the object deleted by the store at sequence.
+func (c *objectCache[E]) delete(id string, sequence uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.advance(sequence)
+	delete(c.entries, id)
+}
+
+// sync drops all entries if the recorded sequence moved past the cached one and reports whether the
+// entries are valid at the recorded sequence. Sequences older than the cached one were read before a
+// later write and are not valid.
+func (c *objectCache[E]) sync(recorded uint64) bool {
+	switch {
+	case recorded < c.sequence:
+		return false
+	case recorded > c.sequence:
+		clear(c.entries)
+		c.sequence = recorded
+	}
+	return true
+}
+
+// advance moves the cached sequence to the one of a write of the store, dropping all entries if
+// sequences were written in between.
+func (c *objectCache[E]) advance(sequence uint64) {
+	if sequence > c.sequence+1 {
+		clear(c.entries)
+	}
+	c.sequence = max(c.sequence, sequence)
+}
+
+// deepCopier is implemented by objects with a typed DeepCopy, e.g. generated by deepcopy-gen.
+type deepCopier[E any] interface {
+	DeepCopy() E
+}
+
+// deepCopyFuncOf returns a copy function using the typed DeepCopy of the objects returned by newFunc,
+// or nil if they don't implement one.
+func deepCopyFuncOf[E api.Object](newFunc func() E) func(E) E {
+	if _, ok := any(newFunc()).(deepCopier[E]); !ok {
+		return nil
+	}
+	return func(obj E) E {
+		return any(obj).(deepCopier[E]).DeepCopy()
+	}
+}
+
+// deepCopy copies obj via DeepCopyFunc, falling back to a JSON round trip.
+func (s *Store[E]) deepCopy(obj E) (E, error) {
+	if s.deepCopyFunc != nil {
+		return s.deepCopyFunc(obj), nil
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return utils.Zero[E](), fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	out := s.newFunc()
+	if err := json.Unmarshal(data, &out); err != nil {
+		return utils.Zero[E](), fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+	return out, nil
+}
+
+// cacheObject caches a copy of obj, written by the store at its sequence.
+func (s *Store[E]) cacheObject(obj E) {
+	if s.cache == nil {
+		return
+	}
+
+	cached, err := s.deepCopy(obj)
+	if err != nil {
+		s.cache.delete(obj.GetID(), obj.GetSequence())
+		return
+	}
+	s.cache.set(obj.GetID(), cached, obj.GetSequence())
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/storeutils/store"
+)
+
+// coalescer holds back update events of an object for a window, delivering only the latest one.
+// Created and Deleted events are delivered immediately, after flushing a pending update of their object.
+type coalescer[E api.Object] struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[string]*pendingEvent[E]
+	deliver func(store.WatchEvent[E])
+}
+
+type pendingEvent[E api.Object] struct {
+	event store.WatchEvent[E]
+	timer *time.Timer
+}
+
+func newCoalescer[E api.Object](window time.Duration, deliver func(store.WatchEvent[E])) *coalescer[E] {
+	return &coalescer[E]{
+		window:  window,
+		pending: map[string]*pendingEvent[E]{},
+		deliver: deliver,
+	}
+}
+
+func (c *coalescer[E]) add(evt store.WatchEvent[E]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := evt.Object.GetID()
+	pending, ok := c.pending[id]
+
+	if evt.Type == store.WatchEventTypeUpdated {
+		if ok {
+			pending.event = evt
+			return
+		}
+
+		pending = &pendingEvent[E]{event: evt}
+		pending.timer = time.AfterFunc(c.window, func() {
+			c.flush(id, pending)
+		})
+		c.pending[id] = pending
+		return
+	}
+
+	if ok {
+		pending.timer.Stop()
+		delete(c.pending, id)
+		c.deliver(pending.event)
+	}
+	c.deliver(evt)
+}
+
+// flush delivers the pending update of the object unless it got delivered already.
+func (c *coalescer[E]) flush(id string, pending *pendingEvent[E]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pending[id] != pending {
+		return
+	}
+	delete(c.pending, id)
+	c.deliver(pending.event)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host_test
+
+import (
+	"testing"
+
+	"github.com/ironcore-dev/provider-utils/storeutils/host"
+	"github.com/ironcore-dev/provider-utils/storeutils/store"
+	"github.com/ironcore-dev/provider-utils/storeutils/storetest"
+)
+
+func TestStoreConformance(t *testing.T) {
+	storetest.RunConformance(t, func() store.Store[*storetest.Dummy] {
+		s, err := host.NewStore[*storetest.Dummy](host.Options[*storetest.Dummy]{
+			Dir:     t.TempDir(),
+			NewFunc: storetest.NewDummy,
+		})
+		if err != nil {
+			t.Fatalf("NewStore: %v", err)
+		}
+		return s
+	})
+}
+
+func TestEncryptedStoreConformance(t *testing.T) {
+	encrypter, err := host.NewAESGCMEncrypter("key", map[string][]byte{"key": make([]byte, 32)})
+	if err != nil {
+		t.Fatalf("NewAESGCMEncrypter: %v", err)
+	}
+
+	storetest.RunConformance(t, func() store.Store[*storetest.Dummy] {
+		s, err := host.NewStore[*storetest.Dummy](host.Options[*storetest.Dummy]{
+			Dir:       t.TempDir(),
+			NewFunc:   storetest.NewDummy,
+			Encrypter: encrypter,
+		})
+		if err != nil {
+			t.Fatalf("NewStore: %v", err)
+		}
+		return s
+	})
+}
+
+func TestCachedStoreConformance(t *testing.T) {
+	storetest.RunConformance(t, func() store.Store[*storetest.Dummy] {
+		s, err := host.NewStore[*storetest.Dummy](host.Options[*storetest.Dummy]{
+			Dir:     t.TempDir(),
+			NewFunc: storetest.NewDummy,
+			Cache:   true,
+		})
+		if err != nil {
+			t.Fatalf("NewStore: %v", err)
+		}
+		return s
+	})
+}
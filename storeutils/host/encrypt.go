@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDecrypt = errors.New("failed to decrypt")
+)
+
+// Encrypter encrypts serialized objects before they are written to disk and decrypts them after reading.
+type Encrypter interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+const (
+	cipherAESGCM byte = 1
+)
+
+// encryptedMagic prefixes every encrypted file, it is followed by the cipher id,
+// the length of the key id, the key id, the nonce and the sealed data.
+var encryptedMagic = []byte("puenc")
+
+// AESGCMEncrypter is an Encrypter using AES-GCM. It encrypts with the active key and
+// decrypts with any known key, allowing keys to be rotated.
+type AESGCMEncrypter struct {
+	activeKeyID string
+	aeads       map[string]cipher.AEAD
+}
+
+// NewAESGCMEncrypter creates an AESGCMEncrypter from the given keys by id. Keys have to be
+// 16, 24 or 32 bytes long. activeKeyID selects the key used for encryption.
+func NewAESGCMEncrypter(activeKeyID string, keys map[string][]byte) (*AESGCMEncrypter, error) {
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %q not found in keys", activeKeyID)
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for keyID, key := range keys {
+		if len(keyID) == 0 || len(keyID) > 255 {
+			return nil, fmt.Errorf("key id %q must be between 1 and 255 bytes", keyID)
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher for key %q: %w", keyID, err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gcm for key %q: %w", keyID, err)
+		}
+		aeads[keyID] = aead
+	}
+
+	return &AESGCMEncrypter{
+		activeKeyID: activeKeyID,
+		aeads:       aeads,
+	}, nil
+}
+
+func (e *AESGCMEncrypter) Encrypt(plaintext []byte) ([]byte, error) {
+	aead := e.aeads[e.activeKeyID]
+
+	header := make([]byte, 0, len(encryptedMagic)+2+len(e.activeKeyID))
+	header = append(header, encryptedMagic...)
+	header = append(header, cipherAESGCM, byte(len(e.activeKeyID)))
+	header = append(header, e.activeKeyID...)
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	data := append(header, nonce...)
+	return aead.Seal(data, nonce, plaintext, header), nil
+}
+
+func (e *AESGCMEncrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, encryptedMagic) || len(ciphertext) < len(encryptedMagic)+2 {
+		return nil, fmt.Errorf("%w: missing encryption header", ErrDecrypt)
+	}
+
+	rest := ciphertext[len(encryptedMagic):]
+	if cipherID := rest[0]; cipherID != cipherAESGCM {
+		return nil, fmt.Errorf("%w: unsupported cipher %d", ErrDecrypt, cipherID)
+	}
+
+	keyIDLen := int(rest[1])
+	rest = rest[2:]
+	if len(rest) < keyIDLen {
+		return nil, fmt.Errorf("%w: truncated encryption header", ErrDecrypt)
+	}
+
+	keyID := string(rest[:keyIDLen])
+	aead, ok := e.aeads[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrDecrypt, keyID)
+	}
+
+	rest = rest[keyIDLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: truncated nonce", ErrDecrypt)
+	}
+
+	header := ciphertext[:len(ciphertext)-len(rest)]
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("%w with key %q: %w", ErrDecrypt, keyID, err)
+	}
+
+	return plaintext, nil
+}
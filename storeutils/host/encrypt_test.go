@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/storeutils/host"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encrypted Store", func() {
+	var (
+		oldKey = bytes.Repeat([]byte{1}, 32)
+		newKey = bytes.Repeat([]byte{2}, 32)
+	)
+
+	newEncryptedStore := func(dir string, activeKeyID string, keys map[string][]byte) *host.Store[*Dummy] {
+		encrypter, err := host.NewAESGCMEncrypter(activeKeyID, keys)
+		Expect(err).NotTo(HaveOccurred())
+
+		s, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: dir,
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+			Encrypter: encrypter,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+
+	It("should round-trip encrypted objects", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		s := newEncryptedStore(dir, "old", map[string][]byte{"old": oldKey})
+
+		By("creating an object")
+		_, err := s.Create(ctx, &Dummy{
+			Metadata: api.Metadata{
+				ID:     "secret-id",
+				Labels: map[string]string{"secret": "plaintext-value"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("checking that the file on disk is not plaintext")
+		data, err := os.ReadFile(filepath.Join(dir, "secret-id"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("plaintext-value"))
+		Expect(string(data)).NotTo(ContainSubstring("secret-id"))
+
+		By("reading the object back")
+		obj, err := s.Get(ctx, "secret-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.Labels).To(HaveKeyWithValue("secret", "plaintext-value"))
+
+		objs, err := s.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(1))
+	})
+
+	It("should decrypt objects written with a rotated key", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		oldStore := newEncryptedStore(dir, "old", map[string][]byte{"old": oldKey})
+		_, err := oldStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "rotated"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		newStore := newEncryptedStore(dir, "new", map[string][]byte{"old": oldKey, "new": newKey})
+		_, err = newStore.Get(ctx, "rotated")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail with ErrDecrypt on a wrong key", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		s := newEncryptedStore(dir, "key", map[string][]byte{"key": oldKey})
+		_, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "wrong-key"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		wrongStore := newEncryptedStore(dir, "key", map[string][]byte{"key": newKey})
+		_, err = wrongStore.Get(ctx, "wrong-key")
+		Expect(err).To(MatchError(host.ErrDecrypt))
+
+		_, err = wrongStore.List(ctx)
+		Expect(err).To(MatchError(host.ErrDecrypt))
+	})
+})
@@ -21,6 +21,12 @@ type Dummy struct {
 	api.Metadata `json:"metadata,omitempty"`
 }
 
+func (d *Dummy) DeepCopy() *Dummy {
+	out := &Dummy{}
+	d.Metadata.DeepCopyInto(&out.Metadata)
+	return out
+}
+
 var (
 	tmpDir     string
 	dummyStore store.Store[*Dummy]
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/storeutils/store"
+)
+
+type listWatch[E api.Object] struct {
+	inner    *watch[E]
+	initial  []E
+	events   chan store.WatchEvent[E]
+	synced   chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// WatchList starts a watch delivering a Created event of every stored object, followed by the
+// events of later writes. The initial objects are listed under the write lock, so every later write
+// is delivered as an event. Like for Watch, writes are dropped once more than WatchBufferSize are
+// pending. The events channel is closed once the watch is stopped or ctx is done.
+func (s *Store[E]) WatchList(ctx context.Context) (store.ListWatch[E], error) {
+	s.sequenceMu.Lock()
+	defer s.sequenceMu.Unlock()
+
+	ids, err := s.ids()
+	if err != nil {
+		return nil, err
+	}
+
+	initial := make([]E, 0, len(ids))
+	for _, id := range ids {
+		obj, err := s.get(id)
+		if err != nil {
+			return nil, err
+		}
+		initial = append(initial, obj)
+	}
+
+	inner, err := s.Watch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &listWatch[E]{
+		inner:   inner.(*watch[E]),
+		initial: initial,
+		events:  make(chan store.WatchEvent[E]),
+		synced:  make(chan struct{}),
+		stop:    make(chan struct{}),
+	}
+	go w.run(ctx)
+
+	return w, nil
+}
+
+func (w *listWatch[E]) run(ctx context.Context) {
+	defer close(w.events)
+	defer w.inner.Stop()
+
+	for _, obj := range w.initial {
+		if !w.send(ctx, store.WatchEvent[E]{
+			Type:     store.WatchEventTypeCreated,
+			Object:   obj,
+			Sequence: obj.GetSequence(),
+		}) {
+			return
+		}
+	}
+	w.initial = nil
+	close(w.synced)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stop:
+			return
+		case evt := <-w.inner.Events():
+			if !w.send(ctx, evt) {
+				return
+			}
+		}
+	}
+}
+
+func (w *listWatch[E]) send(ctx context.Context, evt store.WatchEvent[E]) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-w.stop:
+		return false
+	case w.events <- evt:
+		return true
+	}
+}
+
+func (w *listWatch[E]) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+}
+
+func (w *listWatch[E]) Events() <-chan store.WatchEvent[E] {
+	return w.events
+}
+
+func (w *listWatch[E]) Synced() <-chan struct{} {
+	return w.synced
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	ErrDuplicateID = errors.New("duplicate object id")
+)
+
+type ReplaceOp string
+
+const (
+	ReplaceOpCreate ReplaceOp = "Create"
+	ReplaceOpUpdate ReplaceOp = "Update"
+	ReplaceOpDelete ReplaceOp = "Delete"
+)
+
+// ReplaceOpError is a failed operation of Replace.
+type ReplaceOpError struct {
+	Op  ReplaceOp
+	ID  string
+	Err error
+}
+
+func (e ReplaceOpError) Error() string {
+	return fmt.Sprintf("%s %s: %s", strings.ToLower(string(e.Op)), e.ID, e.Err)
+}
+
+func (e ReplaceOpError) Unwrap() error {
+	return e.Err
+}
+
+// ReplaceError reports the failed operations of Replace.
+type ReplaceError struct {
+	Errors []ReplaceOpError
+}
+
+func (e *ReplaceError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, opErr := range e.Errors {
+		msgs = append(msgs, opErr.Error())
+	}
+	return fmt.Sprintf("failed to replace objects: %s", strings.Join(msgs, "; "))
+}
+
+func (e *ReplaceError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, opErr := range e.Errors {
+		errs = append(errs, opErr)
+	}
+	return errs
+}
+
+// Replace makes the store contents match objs: missing objects are created, changed ones updated and
+// objects not in objs deleted like on Delete, each firing the respective watch event. The store metadata
+// of existing objects (creation time, resource version, sequence, generation, deletion time, finalizers,
+// TTL) is kept. objs are copied, not modified. Replace holds the locks of all affected objects and the
+// write lock throughout, so no other write interleaves and its events have consecutive sequence numbers.
+// Operations are applied independently, failed ones are reported as *ReplaceError. objs listing an id
+// more than once fail with ErrDuplicateID before anything is changed.
+func (s *Store[E]) Replace(_ context.Context, objs []E) error {
+	desired := make([]E, 0, len(objs))
+	desiredIDs := make(map[string]bool, len(objs))
+	for _, obj := range objs {
+		if desiredIDs[obj.GetID()] {
+			return fmt.Errorf("failed to replace objects: %w: %q", ErrDuplicateID, obj.GetID())
+		}
+
+		obj, err := s.deepCopy(obj)
+		if err != nil {
+			return err
+		}
+		desired = append(desired, obj)
+		desiredIDs[obj.GetID()] = true
+	}
+
+	ids, unlock, err := s.lockAll(slices.Collect(maps.Keys(desiredIDs)))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	existing := make(map[string]E, len(ids))
+	for _, id := range ids {
+		obj, err := s.get(id)
+		if err != nil {
+			return fmt.Errorf("failed to read object: %w", err)
+		}
+		existing[id] = obj
+	}
+
+	var opErrors []ReplaceOpError
+	for _, obj := range desired {
+		old, ok := existing[obj.GetID()]
+		if !ok {
+			if err := validateID(obj.GetID()); err != nil {
+				opErrors = append(opErrors, ReplaceOpError{Op: ReplaceOpCreate, ID: obj.GetID(), Err: err})
+				continue
+			}
+			if _, err := s.create(obj); err != nil {
+				opErrors = append(opErrors, ReplaceOpError{Op: ReplaceOpCreate, ID: obj.GetID(), Err: err})
+			}
+			continue
+		}
+
+		obj.SetCreatedAt(old.GetCreatedAt())
+		obj.SetDeletedAt(old.GetDeletedAt())
+		obj.SetFinalizers(old.GetFinalizers())
+		obj.SetTTL(old.GetTTL())
+		obj.SetGeneration(old.GetGeneration())
+		obj.SetObservedGeneration(old.GetObservedGeneration())
+		obj.SetResourceVersion(old.GetResourceVersion())
+		obj.SetSequence(old.GetSequence())
+		if _, err := s.update(old, obj); err != nil {
+			opErrors = append(opErrors, ReplaceOpError{Op: ReplaceOpUpdate, ID: obj.GetID(), Err: err})
+		}
+	}
+
+	for _, id := range ids {
+		if desiredIDs[id] {
+			continue
+		}
+		if err := s.deleteOrFinalize(existing[id]); err != nil {
+			opErrors = append(opErrors, ReplaceOpError{Op: ReplaceOpDelete, ID: id, Err: err})
+		}
+	}
+
+	if len(opErrors) > 0 {
+		return &ReplaceError{Errors: opErrors}
+	}
+
+	return nil
+}
+
+// lockAll locks the given ids and all stored objects in id order, followed by sequenceMu, and returns
+// the sorted ids of the stored objects. Objects created before sequenceMu got locked are locked by
+// retrying, so every stored object is locked once lockAll returns.
+func (s *Store[E]) lockAll(ids []string) ([]string, func(), error) {
+	locked := sets.New(ids...)
+	for {
+		stored, err := s.ids()
+		if err != nil {
+			return nil, nil, err
+		}
+		locked.Insert(stored...)
+
+		sorted := sets.List(locked)
+		for _, id := range sorted {
+			s.idMu.Lock(id)
+		}
+		s.sequenceMu.Lock()
+		unlock := func() {
+			s.sequenceMu.Unlock()
+			for _, id := range sorted {
+				s.idMu.Unlock(id)
+			}
+		}
+
+		stored, err = s.ids()
+		if err != nil {
+			unlock()
+			return nil, nil, err
+		}
+		if locked.HasAll(stored...) {
+			slices.Sort(stored)
+			return stored, unlock, nil
+		}
+		unlock()
+	}
+}
@@ -4,12 +4,15 @@
 package host
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +22,7 @@ import (
 	"github.com/ironcore-dev/provider-utils/storeutils/utils"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type Options[E api.Object] struct {
@@ -26,12 +30,31 @@ type Options[E api.Object] struct {
 	NewFunc         func() E
 	CreateStrategy  CreateStrategy[E]
 	WatchBufferSize int
+	// Encrypter, if set, encrypts objects at rest.
+	Encrypter Encrypter
+	// ReapInterval is the interval in which Start deletes objects whose TTL expired.
+	ReapInterval time.Duration
+	// Cache keeps deserialized objects in memory, so Get and List only read the recorded sequence.
+	// Writes of other stores on the same directory are detected by the sequence they record. It
+	// requires DeepCopyFunc or objects implementing DeepCopy.
+	Cache bool
+	// DeepCopyFunc copies objects, e.g. cached ones on the way out. Defaults to the DeepCopy of the
+	// objects if they implement it, else to a JSON round trip.
+	DeepCopyFunc func(E) E
+	// CoalesceWindow, if set, collapses the updates of an object within the window into a single event
+	// of its latest state. Created and Deleted events are never coalesced. Coalesced events skip
+	// sequence numbers and may arrive after events of later writes to other objects.
+	CoalesceWindow time.Duration
 }
 
 func (o *Options[E]) Defaults() {
 	if o.WatchBufferSize <= 0 {
 		o.WatchBufferSize = 20
 	}
+
+	if o.ReapInterval <= 0 {
+		o.ReapInterval = 30 * time.Second
+	}
 }
 
 func NewStore[E api.Object](opts Options[E]) (*Store[E], error) {
@@ -41,21 +64,41 @@ func NewStore[E api.Object](opts Options[E]) (*Store[E], error) {
 		return nil, fmt.Errorf("must specify opts.NewFunc")
 	}
 
+	deepCopyFunc := opts.DeepCopyFunc
+	if deepCopyFunc == nil {
+		deepCopyFunc = deepCopyFuncOf(opts.NewFunc)
+	}
+	if opts.Cache && deepCopyFunc == nil {
+		return nil, fmt.Errorf("must specify opts.DeepCopyFunc or implement DeepCopy to use opts.Cache")
+	}
+
 	if err := os.MkdirAll(opts.Dir, os.ModePerm); err != nil {
 		return nil, fmt.Errorf("error creating store directory: %w", err)
 	}
 
-	return &Store[E]{
+	s := &Store[E]{
 		dir: opts.Dir,
 
 		idMu: utilssync.NewMutexMap[string](),
 
 		newFunc:        opts.NewFunc,
 		createStrategy: opts.CreateStrategy,
+		encrypter:      opts.Encrypter,
+		reapInterval:   opts.ReapInterval,
+		deepCopyFunc:   deepCopyFunc,
 
 		watches:         sets.New[*watch[E]](),
 		watchBufferSize: opts.WatchBufferSize,
-	}, nil
+	}
+	if opts.Cache {
+		s.cache = newObjectCache[E]()
+	}
+	if opts.CoalesceWindow > 0 {
+		s.coalescer = newCoalescer(opts.CoalesceWindow, s.deliver)
+	}
+	s.sequence = s.lastSequence()
+
+	return s, nil
 }
 
 type Store[E api.Object] struct {
@@ -65,12 +108,27 @@ type Store[E api.Object] struct {
 
 	newFunc        func() E
 	createStrategy CreateStrategy[E]
+	encrypter      Encrypter
+	reapInterval   time.Duration
+
+	cache        *objectCache[E]
+	deepCopyFunc func(E) E
+
+	// sequenceMu serializes writes so watch events are delivered in sequence order. It is acquired
+	// after the locks of the written objects.
+	sequenceMu sync.Mutex
+	sequence   uint64
 
 	watchBufferSize int
 	watchesMu       sync.RWMutex
 	watches         sets.Set[*watch[E]]
+	coalescer       *coalescer[E]
 }
 
+// sequenceFile is the file in the store directory keeping the last issued sequence number, so numbers of
+// deleted objects are not issued again after reopening the store.
+const sequenceFile = ".sequence"
+
 type CreateStrategy[E api.Object] interface {
 	PrepareForCreate(obj E)
 }
@@ -88,6 +146,13 @@ func (s *Store[E]) Create(_ context.Context, obj E) (E, error) {
 		return utils.Zero[E](), fmt.Errorf("failed to get object with id %q %w", obj.GetID(), err)
 	}
 
+	s.sequenceMu.Lock()
+	defer s.sequenceMu.Unlock()
+
+	return s.create(obj)
+}
+
+func (s *Store[E]) create(obj E) (E, error) {
 	if s.createStrategy != nil {
 		s.createStrategy.PrepareForCreate(obj)
 	}
@@ -95,17 +160,7 @@ func (s *Store[E]) Create(_ context.Context, obj E) (E, error) {
 	obj.SetCreatedAt(time.Now())
 	obj.IncrementResourceVersion()
 
-	obj, err = s.set(obj)
-	if err != nil {
-		return utils.Zero[E](), err
-	}
-
-	s.enqueue(store.WatchEvent[E]{
-		Type:   store.WatchEventTypeCreated,
-		Object: obj,
-	})
-
-	return obj, nil
+	return s.write(obj, store.WatchEventTypeCreated)
 }
 
 func (s *Store[E]) Get(_ context.Context, id string) (E, error) {
@@ -120,6 +175,8 @@ func (s *Store[E]) Get(_ context.Context, id string) (E, error) {
 	return object, nil
 }
 
+// Update increments the generation of changed objects, unless the caller already bumped it
+// or only the observed generation changed.
 func (s *Store[E]) Update(_ context.Context, obj E) (E, error) {
 	s.idMu.Lock(obj.GetID())
 	defer s.idMu.Unlock(obj.GetID())
@@ -129,6 +186,43 @@ func (s *Store[E]) Update(_ context.Context, obj E) (E, error) {
 		return utils.Zero[E](), err
 	}
 
+	s.sequenceMu.Lock()
+	defer s.sequenceMu.Unlock()
+
+	return s.update(oldObj, obj)
+}
+
+// Patch applies mutate to the stored object and writes it back, holding the lock of the object
+// throughout, so concurrent patches of different fields don't lose each other's changes. Errors
+// of mutate abort the patch without writing.
+func (s *Store[E]) Patch(_ context.Context, id string, mutate func(E) error) error {
+	s.idMu.Lock(id)
+	defer s.idMu.Unlock(id)
+
+	oldObj, err := s.get(id)
+	if err != nil {
+		return err
+	}
+
+	obj, err := s.deepCopy(oldObj)
+	if err != nil {
+		return err
+	}
+	if err := mutate(obj); err != nil {
+		return err
+	}
+	if obj.GetID() != id {
+		return fmt.Errorf("failed to patch object: id changed from %q to %q", id, obj.GetID())
+	}
+
+	s.sequenceMu.Lock()
+	defer s.sequenceMu.Unlock()
+
+	_, err = s.update(oldObj, obj)
+	return err
+}
+
+func (s *Store[E]) update(oldObj, obj E) (E, error) {
 	if obj.GetDeletedAt() != nil && len(obj.GetFinalizers()) == 0 {
 		if err := s.delete(obj); err != nil {
 			return utils.Zero[E](), fmt.Errorf("failed to delete object metadata: %w", err)
@@ -144,19 +238,30 @@ func (s *Store[E]) Update(_ context.Context, obj E) (E, error) {
 		return obj, nil
 	}
 
+	if obj.GetGeneration() == oldObj.GetGeneration() && !onlyObservedGenerationChanged(oldObj, obj) {
+		obj.SetGeneration(obj.GetGeneration() + 1)
+	}
 	obj.IncrementResourceVersion()
 
-	obj, err = s.set(obj)
-	if err != nil {
-		return utils.Zero[E](), err
-	}
+	return s.write(obj, store.WatchEventTypeUpdated)
+}
 
-	s.enqueue(store.WatchEvent[E]{
-		Type:   store.WatchEventTypeUpdated,
-		Object: obj,
-	})
+// onlyObservedGenerationChanged reports whether obj differs from oldObj in the observed generation only,
+// so recording a reconcile does not bump the generation. Objects are compared in their stored encoding.
+func onlyObservedGenerationChanged[E api.Object](oldObj, obj E) bool {
+	observedGeneration := obj.GetObservedGeneration()
+	obj.SetObservedGeneration(oldObj.GetObservedGeneration())
+	defer obj.SetObservedGeneration(observedGeneration)
 
-	return obj, nil
+	oldData, err := json.Marshal(oldObj)
+	if err != nil {
+		return false
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(oldData, data)
 }
 
 func (s *Store[E]) Delete(_ context.Context, id string) error {
@@ -168,6 +273,13 @@ func (s *Store[E]) Delete(_ context.Context, id string) error {
 		return err
 	}
 
+	s.sequenceMu.Lock()
+	defer s.sequenceMu.Unlock()
+
+	return s.deleteOrFinalize(obj)
+}
+
+func (s *Store[E]) deleteOrFinalize(obj E) error {
 	if len(obj.GetFinalizers()) == 0 {
 		return s.delete(obj)
 	}
@@ -180,32 +292,23 @@ func (s *Store[E]) Delete(_ context.Context, id string) error {
 	obj.SetDeletedAt(&now)
 	obj.IncrementResourceVersion()
 
-	if _, err := s.set(obj); err != nil {
+	if _, err := s.write(obj, store.WatchEventTypeDeleted); err != nil {
 		return fmt.Errorf("failed to set object metadata: %w", err)
 	}
 
-	s.enqueue(store.WatchEvent[E]{
-		Type:   store.WatchEventTypeDeleted,
-		Object: obj,
-	})
-
 	return nil
 }
 
 func (s *Store[E]) List(ctx context.Context) ([]E, error) {
-	entries, err := os.ReadDir(s.dir)
+	ids, err := s.ids()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list objects: %w", err)
+		return nil, err
 	}
 
 	//nolint:prealloc
 	var objs []E
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		object, err := s.Get(ctx, entry.Name())
+	for _, id := range ids {
+		object, err := s.Get(ctx, id)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read object: %w", err)
 		}
@@ -216,13 +319,102 @@ func (s *Store[E]) List(ctx context.Context) ([]E, error) {
 	return objs, nil
 }
 
-func (s *Store[E]) Watch(_ context.Context) (store.Watch[E], error) {
+// Start deletes objects whose TTL expired until the context is done. Objects with finalizers
+// are marked as deleted like on Delete.
+func (s *Store[E]) Start(ctx context.Context) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		s.reapExpired(time.Now())
+	}, s.reapInterval)
+}
+
+// reapExpired deletes all objects expired at now. Objects failing to be read or deleted
+// are retried on the next run.
+func (s *Store[E]) reapExpired(now time.Time) {
+	ids, err := s.ids()
+	if err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		s.reapIfExpired(id, now)
+	}
+}
+
+func (s *Store[E]) reapIfExpired(id string, now time.Time) {
+	s.idMu.Lock(id)
+	defer s.idMu.Unlock(id)
+
+	obj, err := s.get(id)
+	if err != nil || !expired(obj, now) || obj.GetDeletedAt() != nil {
+		return
+	}
+
+	s.sequenceMu.Lock()
+	defer s.sequenceMu.Unlock()
+
+	_ = s.deleteOrFinalize(obj)
+}
+
+func expired(obj api.Object, now time.Time) bool {
+	return obj.GetTTL() > 0 && !now.Before(obj.GetCreatedAt().Add(obj.GetTTL()))
+}
+
+func (s *Store[E]) Count(_ context.Context) (int, error) {
+	ids, err := s.ids()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+func (s *Store[E]) Exists(_ context.Context, id string) (bool, error) {
+	if err := validateID(id); err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(filepath.Join(s.dir, id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	return !info.IsDir(), nil
+}
+
+// ids returns the ids of all objects in the store directory without reading the object files.
+func (s *Store[E]) ids() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == sequenceFile {
+			continue
+		}
+
+		ids = append(ids, entry.Name())
+	}
+
+	return ids, nil
+}
+
+func (s *Store[E]) Watch(ctx context.Context) (store.Watch[E], error) {
+	return s.WatchWithOptions(ctx, store.WatchOptions[E]{})
+}
+
+func (s *Store[E]) WatchWithOptions(_ context.Context, opts store.WatchOptions[E]) (store.Watch[E], error) {
 	s.watchesMu.Lock()
 	defer s.watchesMu.Unlock()
 
 	w := &watch[E]{
-		store:  s,
-		events: make(chan store.WatchEvent[E], s.watchBufferSize),
+		store:     s,
+		events:    make(chan store.WatchEvent[E], s.watchBufferSize),
+		predicate: opts.Predicate,
 	}
 
 	s.watches.Insert(w)
@@ -230,16 +422,58 @@ func (s *Store[E]) Watch(_ context.Context) (store.Watch[E], error) {
 	return w, nil
 }
 
+// validateID rejects ids that don't name a file in the store directory or name the sequence file.
+func validateID(id string) error {
+	if id == "" || id == "." || id == ".." || id != filepath.Base(id) || id == sequenceFile {
+		return fmt.Errorf("invalid object id %q", id)
+	}
+	return nil
+}
+
 func (s *Store[E]) get(id string) (E, error) {
+	if err := validateID(id); err != nil {
+		return utils.Zero[E](), err
+	}
+
+	var recorded uint64
+	if s.cache != nil {
+		recorded = s.recordedSequence()
+		if obj, ok := s.cache.get(id, recorded); ok {
+			return s.deepCopy(obj)
+		}
+	}
+
 	file, err := os.ReadFile(filepath.Join(s.dir, id))
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return utils.Zero[E](), fmt.Errorf("failed to read file: %w", err)
 		}
-
 		return utils.Zero[E](), fmt.Errorf("object with id %q %w", id, store.ErrNotFound)
 	}
 
+	obj, err := s.decode(id, file)
+	if err != nil {
+		return utils.Zero[E](), err
+	}
+
+	if s.cache != nil {
+		if cached, err := s.deepCopy(obj); err == nil {
+			s.cache.add(id, cached, recorded)
+		}
+	}
+	return obj, nil
+}
+
+// decode decrypts and unmarshals the stored data of the object with the given id.
+func (s *Store[E]) decode(id string, file []byte) (E, error) {
+	var err error
+	if s.encrypter != nil {
+		file, err = s.encrypter.Decrypt(file)
+		if err != nil {
+			return utils.Zero[E](), fmt.Errorf("failed to decrypt object from file %s: %w", id, err)
+		}
+	}
+
 	obj := s.newFunc()
 	if err := json.Unmarshal(file, &obj); err != nil {
 		return utils.Zero[E](), fmt.Errorf("failed to unmarshal object from file %s: %w", id, err)
@@ -254,26 +488,115 @@ func (s *Store[E]) set(obj E) (E, error) {
 		return utils.Zero[E](), fmt.Errorf("failed to marshal obj: %w", err)
 	}
 
+	if s.encrypter != nil {
+		data, err = s.encrypter.Encrypt(data)
+		if err != nil {
+			return utils.Zero[E](), fmt.Errorf("failed to encrypt obj: %w", err)
+		}
+	}
+
 	if err := os.WriteFile(filepath.Join(s.dir, obj.GetID()), data, 0666); err != nil {
-		return utils.Zero[E](), nil
+		return utils.Zero[E](), fmt.Errorf("failed to write obj: %w", err)
 	}
 
+	s.cacheObject(obj)
 	return obj, nil
 }
 
+// write stores obj with the next sequence number and enqueues the resulting watch event. If obj
+// cannot be stored, the sequence number is handed back and no event is enqueued. The caller must hold
+// sequenceMu.
+func (s *Store[E]) write(obj E, eventType store.WatchEventType) (E, error) {
+	if err := s.persistSequence(s.sequence + 1); err != nil {
+		return utils.Zero[E](), err
+	}
+	sequence := obj.GetSequence()
+	obj.SetSequence(s.sequence + 1)
+	stored, err := s.set(obj)
+	if err != nil {
+		obj.SetSequence(sequence)
+		if persistErr := s.persistSequence(s.sequence); persistErr != nil {
+			return utils.Zero[E](), errors.Join(err, persistErr)
+		}
+		return utils.Zero[E](), err
+	}
+	obj = stored
+	s.sequence++
+
+	s.enqueue(store.WatchEvent[E]{
+		Type:     eventType,
+		Object:   obj,
+		Sequence: s.sequence,
+	})
+
+	return obj, nil
+}
+
+// delete removes obj with the next sequence number and enqueues the resulting watch event. The caller
+// must hold sequenceMu.
 func (s *Store[E]) delete(obj E) error {
+	if err := s.persistSequence(s.sequence + 1); err != nil {
+		return err
+	}
 	if err := os.Remove(filepath.Join(s.dir, obj.GetID())); err != nil {
 		return fmt.Errorf("failed to delete object from store: %w", err)
 	}
+	s.sequence++
+	obj.SetSequence(s.sequence)
+	if s.cache != nil {
+		s.cache.delete(obj.GetID(), s.sequence)
+	}
 
 	s.enqueue(store.WatchEvent[E]{
-		Type:   store.WatchEventTypeDeleted,
-		Object: obj,
+		Type:     store.WatchEventTypeDeleted,
+		Object:   obj,
+		Sequence: s.sequence,
 	})
 
 	return nil
 }
 
+// persistSequence records sequence as the last issued sequence number. It is written before the
+// object, so a failed write skips a number rather than issuing it twice.
+func (s *Store[E]) persistSequence(sequence uint64) error {
+	if err := os.WriteFile(filepath.Join(s.dir, sequenceFile), []byte(strconv.FormatUint(sequence, 10)), 0666); err != nil {
+		return fmt.Errorf("failed to write sequence: %w", err)
+	}
+	return nil
+}
+
+// recordedSequence returns the last sequence recorded in the store directory, zero if none is.
+func (s *Store[E]) recordedSequence() uint64 {
+	data, err := os.ReadFile(filepath.Join(s.dir, sequenceFile))
+	if err != nil {
+		return 0
+	}
+	sequence, _ := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return sequence
+}
+
+// lastSequence returns the last issued sequence number, so the sequence keeps increasing across
+// restarts. It is the higher of the recorded sequence and the sequences of the stored objects, which
+// covers stores written before the sequence got recorded. Objects failing to be read are skipped.
+func (s *Store[E]) lastSequence() uint64 {
+	sequence := s.recordedSequence()
+
+	ids, err := s.ids()
+	if err != nil {
+		return sequence
+	}
+
+	for _, id := range ids {
+		obj, err := s.get(id)
+		if err != nil {
+			continue
+		}
+		sequence = max(sequence, obj.GetSequence())
+	}
+
+	return sequence
+}
+
 func (s *Store[E]) watchHandlers() []*watch[E] {
 	s.watchesMu.RLock()
 	defer s.watchesMu.RUnlock()
@@ -282,7 +605,19 @@ func (s *Store[E]) watchHandlers() []*watch[E] {
 }
 
 func (s *Store[E]) enqueue(evt store.WatchEvent[E]) {
+	if s.coalescer != nil {
+		s.coalescer.add(evt)
+		return
+	}
+	s.deliver(evt)
+}
+
+func (s *Store[E]) deliver(evt store.WatchEvent[E]) {
 	for _, handler := range s.watchHandlers() {
+		if handler.predicate != nil && !handler.predicate(evt.Object) {
+			continue
+		}
+
 		select {
 		case handler.events <- evt:
 		default:
@@ -4,10 +4,17 @@
 package host_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/storeutils/host"
 	"github.com/ironcore-dev/provider-utils/storeutils/store"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -15,6 +22,203 @@ import (
 
 var _ = Describe("Store", func() {
 
+	It("should track the generation across updates and reconciles", func(ctx SpecContext) {
+		created, err := dummyStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "generation"}})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(dummyStore.Delete, context.Background(), "generation")
+		Expect(api.NeedsReconcile(created.Metadata)).To(BeFalse())
+
+		createdGeneration := created.Generation
+
+		By("updating the object")
+		created.Labels = map[string]string{"changed": "true"}
+		updated, err := dummyStore.Update(ctx, created)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Generation).To(Equal(createdGeneration + 1))
+		Expect(api.NeedsReconcile(updated.Metadata)).To(BeTrue())
+
+		By("recording the reconcile without bumping the generation")
+		api.MarkObserved(updated)
+		observed, err := dummyStore.Update(ctx, updated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(observed.Generation).To(Equal(createdGeneration + 1))
+		Expect(api.NeedsReconcile(observed.Metadata)).To(BeFalse())
+
+		By("not bumping twice if the caller already bumped the generation")
+		api.BumpGeneration(observed)
+		bumped, err := dummyStore.Update(ctx, observed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bumped.Generation).To(Equal(createdGeneration + 2))
+		Expect(api.NeedsReconcile(bumped.Metadata)).To(BeTrue())
+	})
+
+	It("should replace the store contents", func(ctx SpecContext) {
+		replaceStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: GinkgoT().TempDir(),
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("seeding the store")
+		for _, id := range []string{"a", "b", "c"} {
+			_, err := replaceStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: id}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		watch, err := replaceStore.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+
+		By("replacing with a partially overlapping set")
+		err = replaceStore.Replace(ctx, []*Dummy{
+			{Metadata: api.Metadata{ID: "b", Labels: map[string]string{"changed": "true"}}},
+			{Metadata: api.Metadata{ID: "c"}},
+			{Metadata: api.Metadata{ID: "d"}},
+			{Metadata: api.Metadata{ID: ""}},
+		})
+		var replaceErr *host.ReplaceError
+		Expect(errors.As(err, &replaceErr)).To(BeTrue())
+		Expect(replaceErr.Errors).To(ConsistOf(SatisfyAll(
+			HaveField("Op", host.ReplaceOpCreate),
+			HaveField("ID", ""),
+		)))
+
+		By("asserting the final contents")
+		objs, err := replaceStore.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(ConsistOf(
+			HaveField("ID", "b"),
+			HaveField("ID", "c"),
+			HaveField("ID", "d"),
+		))
+		b, err := replaceStore.Get(ctx, "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.Labels).To(Equal(map[string]string{"changed": "true"}))
+		Expect(b.ResourceVersion).To(Equal(uint64(2)))
+
+		By("asserting the events")
+		var events []store.WatchEvent[*Dummy]
+		for range 3 {
+			var event store.WatchEvent[*Dummy]
+			Eventually(watch.Events()).Should(Receive(&event))
+			events = append(events, event)
+		}
+		Expect(events).To(ConsistOf(
+			SatisfyAll(HaveField("Type", store.WatchEventTypeUpdated), HaveField("Object.ID", "b")),
+			SatisfyAll(HaveField("Type", store.WatchEventTypeCreated), HaveField("Object.ID", "d")),
+			SatisfyAll(HaveField("Type", store.WatchEventTypeDeleted), HaveField("Object.ID", "a")),
+		))
+		Consistently(watch.Events()).ShouldNot(Receive())
+
+		By("rejecting duplicate ids without changing anything")
+		err = replaceStore.Replace(ctx, []*Dummy{
+			{Metadata: api.Metadata{ID: "b"}},
+			{Metadata: api.Metadata{ID: "e"}},
+			{Metadata: api.Metadata{ID: "e", Labels: map[string]string{"changed": "true"}}},
+		})
+		Expect(err).To(MatchError(host.ErrDuplicateID))
+		Expect(replaceStore.Count(ctx)).To(Equal(3))
+		Consistently(watch.Events()).ShouldNot(Receive())
+	})
+
+	It("should keep finalizers and not modify the objects on replace", func(ctx SpecContext) {
+		replaceStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: GinkgoT().TempDir(),
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("seeding the store with finalized objects")
+		for _, id := range []string{"kept", "finalized"} {
+			_, err := replaceStore.Create(ctx, &Dummy{Metadata: api.Metadata{
+				ID:         id,
+				Finalizers: []string{"example.com/finalizer"},
+				TTL:        time.Hour,
+			}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		watch, err := replaceStore.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+
+		By("replacing with a changed and a new object")
+		desired := []*Dummy{
+			{Metadata: api.Metadata{ID: "kept", Labels: map[string]string{"changed": "true"}}},
+			{Metadata: api.Metadata{ID: "new"}},
+		}
+		Expect(replaceStore.Replace(ctx, desired)).To(Succeed())
+		Expect(desired[0].Metadata).To(Equal(api.Metadata{ID: "kept", Labels: map[string]string{"changed": "true"}}))
+		Expect(desired[1].Metadata).To(Equal(api.Metadata{ID: "new"}))
+
+		By("keeping the finalizers and ttl of the updated object")
+		kept, err := replaceStore.Get(ctx, "kept")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kept.Finalizers).To(Equal([]string{"example.com/finalizer"}))
+		Expect(kept.TTL).To(Equal(time.Hour))
+
+		By("marking the finalized object as deleted instead of deleting it")
+		finalized, err := replaceStore.Get(ctx, "finalized")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finalized.DeletedAt).NotTo(BeNil())
+
+		By("numbering the events consecutively")
+		var sequences []uint64
+		for range 3 {
+			var event store.WatchEvent[*Dummy]
+			Eventually(watch.Events()).Should(Receive(&event))
+			sequences = append(sequences, event.Sequence)
+		}
+		Expect(sequences).To(Equal([]uint64{3, 4, 5}))
+	})
+
+	It("should delete objects once their ttl expired", func(ctx SpecContext) {
+		ttlStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: GinkgoT().TempDir(),
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+			ReapInterval: 50 * time.Millisecond,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		innerCtx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		go ttlStore.Start(innerCtx)
+
+		By("creating a watch")
+		watch, err := ttlStore.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+
+		By("creating an object with a short ttl and one without ttl")
+		obj, err := ttlStore.Create(ctx, &Dummy{
+			Metadata: api.Metadata{ID: "ttl", TTL: 200 * time.Millisecond},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ttlStore.Create(ctx, &Dummy{
+			Metadata: api.Metadata{ID: "no-ttl"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(watch.Events()).Should(Receive(HaveField("Object.ID", "ttl")))
+		Eventually(watch.Events()).Should(Receive(HaveField("Object.ID", "no-ttl")))
+
+		By("checking that the expired object got deleted and the event got fired")
+		Eventually(watch.Events()).Should(Receive(SatisfyAll(
+			HaveField("Type", store.WatchEventTypeDeleted),
+			HaveField("Object.ID", obj.ID),
+		)))
+		_, err = ttlStore.Get(ctx, "ttl")
+		Expect(err).To(MatchError(store.ErrNotFound))
+
+		_, err = ttlStore.Get(ctx, "no-ttl")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 	It("should correctly create a object", func(ctx SpecContext) {
 		By("creating a watch")
 		watch, err := dummyStore.Watch(ctx)
@@ -42,4 +246,505 @@ var _ = Describe("Store", func() {
 		}
 		Eventually(watch.Events()).Should(Receive(event))
 	})
+
+	It("should deliver the initial objects before live events via WatchList", func(ctx SpecContext) {
+		hostStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: GinkgoT().TempDir(),
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("creating the initial objects")
+		for _, id := range []string{"initial-a", "initial-b"} {
+			_, err := hostStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: id}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("starting the list watch")
+		watch, err := hostStore.WatchList(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+		Consistently(watch.Synced()).ShouldNot(BeClosed())
+
+		By("receiving the initial objects")
+		var initial []string
+		for range 2 {
+			var event store.WatchEvent[*Dummy]
+			Eventually(watch.Events()).Should(Receive(&event))
+			Expect(event.Type).To(Equal(store.WatchEventTypeCreated))
+			initial = append(initial, event.Object.ID)
+		}
+		Expect(initial).To(ConsistOf("initial-a", "initial-b"))
+		Eventually(watch.Synced()).Should(BeClosed())
+
+		By("receiving live events")
+		_, err = hostStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "live"}})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(watch.Events()).Should(Receive(SatisfyAll(
+			HaveField("Type", store.WatchEventTypeCreated),
+			HaveField("Object.ID", "live"),
+		)))
+
+		By("closing the events once stopped")
+		watch.Stop()
+		Eventually(watch.Events()).Should(BeClosed())
+	})
+
+	It("should not report the store directory or subdirectories as existing objects", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		existsStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: dir,
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Mkdir(filepath.Join(dir, "subdir"), 0777)).To(Succeed())
+
+		_, err = existsStore.Exists(ctx, "")
+		Expect(err).To(HaveOccurred())
+		Expect(existsStore.Exists(ctx, "subdir")).To(BeFalse())
+	})
+
+	It("should only deliver events of objects matching the watch predicate", func(ctx SpecContext) {
+		hostStore, ok := dummyStore.(*host.Store[*Dummy])
+		Expect(ok).To(BeTrue())
+
+		By("creating a filtered watch")
+		watch, err := hostStore.WatchWithOptions(ctx, store.WatchOptions[*Dummy]{
+			Predicate: func(obj *Dummy) bool {
+				return obj.Labels["watched"] == "true"
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+
+		By("creating a non-matching and a matching object")
+		_, err = dummyStore.Create(ctx, &Dummy{
+			Metadata: api.Metadata{ID: "predicate-ignored"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		matching, err := dummyStore.Create(ctx, &Dummy{
+			Metadata: api.Metadata{
+				ID:     "predicate-matching",
+				Labels: map[string]string{"watched": "true"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("updating and deleting both objects")
+		Expect(dummyStore.Delete(ctx, "predicate-ignored")).To(Succeed())
+
+		matching.Annotations = map[string]string{"updated": "true"}
+		_, err = dummyStore.Update(ctx, matching)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dummyStore.Delete(ctx, "predicate-matching")).To(Succeed())
+
+		By("checking that only the matching object's events arrived")
+		var received []store.WatchEvent[*Dummy]
+		Eventually(func() []store.WatchEvent[*Dummy] {
+			select {
+			case evt := <-watch.Events():
+				received = append(received, evt)
+			default:
+			}
+			return received
+		}).Should(HaveLen(3))
+		Consistently(watch.Events()).ShouldNot(Receive())
+
+		for _, evt := range received {
+			Expect(evt.Object.ID).To(Equal("predicate-matching"))
+		}
+		Expect(received[0].Type).To(Equal(store.WatchEventTypeCreated))
+		Expect(received[1].Type).To(Equal(store.WatchEventTypeUpdated))
+		Expect(received[2].Type).To(Equal(store.WatchEventTypeDeleted))
+	})
+
+	It("should number the events with an increasing sequence", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		newFunc := func() *Dummy {
+			return &Dummy{}
+		}
+		sequenceStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{Dir: dir, NewFunc: newFunc})
+		Expect(err).NotTo(HaveOccurred())
+
+		watch, err := sequenceStore.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+
+		By("creating, updating and deleting objects")
+		a, err := sequenceStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "a"}})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = sequenceStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "b"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		a.Labels = map[string]string{"changed": "true"}
+		_, err = sequenceStore.Update(ctx, a)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sequenceStore.Delete(ctx, "b")).To(Succeed())
+
+		By("asserting the sequence increases by one per event")
+		var sequences []uint64
+		for _, eventType := range []store.WatchEventType{
+			store.WatchEventTypeCreated,
+			store.WatchEventTypeCreated,
+			store.WatchEventTypeUpdated,
+			store.WatchEventTypeDeleted,
+		} {
+			var event store.WatchEvent[*Dummy]
+			Eventually(watch.Events()).Should(Receive(&event))
+			Expect(event.Type).To(Equal(eventType))
+			sequences = append(sequences, event.Sequence)
+		}
+		Expect(sequences).To(Equal([]uint64{1, 2, 3, 4}))
+
+		By("storing the sequence of the last write with the object")
+		stored, err := sequenceStore.Get(ctx, "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.Sequence).To(Equal(uint64(3)))
+
+		By("continuing the sequence after reopening the store")
+		reopened, err := host.NewStore[*Dummy](host.Options[*Dummy]{Dir: dir, NewFunc: newFunc})
+		Expect(err).NotTo(HaveOccurred())
+		c, err := reopened.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "c"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Sequence).To(BeNumerically(">", sequences[len(sequences)-1]))
+	})
+
+	It("should not issue a sequence or event for a failed write", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		sequenceStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: dir,
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+			Cache: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		watch, err := sequenceStore.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+
+		a, err := sequenceStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "a"}})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(watch.Events()).Should(Receive())
+
+		By("failing to update an object whose file cannot be written")
+		Expect(os.Remove(filepath.Join(dir, "a"))).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(dir, "a", "blocked"), 0777)).To(Succeed())
+
+		a.Labels = map[string]string{"changed": "true"}
+		_, err = sequenceStore.Update(ctx, a)
+		Expect(err).To(HaveOccurred())
+		Consistently(watch.Events()).ShouldNot(Receive())
+
+		By("issuing the sequence to the next write")
+		b, err := sequenceStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "b"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.Sequence).To(Equal(uint64(2)))
+
+		var event store.WatchEvent[*Dummy]
+		Eventually(watch.Events()).Should(Receive(&event))
+		Expect(event.Object.ID).To(Equal("b"))
+	})
+
+	It("should not reissue the sequence of a deleted object after reopening the store", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		newFunc := func() *Dummy {
+			return &Dummy{}
+		}
+		sequenceStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{Dir: dir, NewFunc: newFunc})
+		Expect(err).NotTo(HaveOccurred())
+
+		watch, err := sequenceStore.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+
+		By("creating and deleting an object")
+		_, err = sequenceStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "a"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sequenceStore.Delete(ctx, "a")).To(Succeed())
+
+		var deleted store.WatchEvent[*Dummy]
+		Eventually(watch.Events()).Should(Receive())
+		Eventually(watch.Events()).Should(Receive(&deleted))
+		Expect(deleted.Type).To(Equal(store.WatchEventTypeDeleted))
+
+		By("continuing after the sequence of the delete event")
+		reopened, err := host.NewStore[*Dummy](host.Options[*Dummy]{Dir: dir, NewFunc: newFunc})
+		Expect(err).NotTo(HaveOccurred())
+		b, err := reopened.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "b"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.Sequence).To(BeNumerically(">=", deleted.Sequence+1))
+
+		By("not listing the recorded sequence as object")
+		Expect(reopened.Count(ctx)).To(Equal(1))
+	})
+
+	It("should coalesce rapid updates of an object", func(ctx SpecContext) {
+		coalescingStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: GinkgoT().TempDir(),
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+			CoalesceWindow: 200 * time.Millisecond,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		watch, err := coalescingStore.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+
+		obj, err := coalescingStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "coalesced"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("updating the object in a burst")
+		const updates = 50
+		for i := range updates {
+			next := *obj
+			next.Labels = map[string]string{"update": fmt.Sprint(i)}
+			obj, err = coalescingStore.Update(ctx, &next)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("receiving the created event and far fewer updates ending in the final state")
+		var event store.WatchEvent[*Dummy]
+		Eventually(watch.Events()).Should(Receive(&event))
+		Expect(event.Type).To(Equal(store.WatchEventTypeCreated))
+
+		var received []store.WatchEvent[*Dummy]
+		Eventually(func() string {
+			select {
+			case evt := <-watch.Events():
+				received = append(received, evt)
+			default:
+			}
+			if len(received) == 0 {
+				return ""
+			}
+			return received[len(received)-1].Object.Labels["update"]
+		}).Should(Equal(fmt.Sprint(updates - 1)))
+		Consistently(watch.Events(), 300*time.Millisecond).ShouldNot(Receive())
+		Expect(len(received)).To(BeNumerically("<", updates/5))
+		for _, evt := range received {
+			Expect(evt.Type).To(Equal(store.WatchEventTypeUpdated))
+		}
+
+		By("delivering the pending update before a delete")
+		next := *obj
+		next.Labels = map[string]string{"update": "last"}
+		_, err = coalescingStore.Update(ctx, &next)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(coalescingStore.Delete(ctx, "coalesced")).To(Succeed())
+
+		Eventually(watch.Events()).Should(Receive(&event))
+		Expect(event.Type).To(Equal(store.WatchEventTypeUpdated))
+		Expect(event.Object.Labels["update"]).To(Equal("last"))
+		Eventually(watch.Events()).Should(Receive(&event))
+		Expect(event.Type).To(Equal(store.WatchEventTypeDeleted))
+	})
+
+	It("should not lose concurrent patches of different fields", func(ctx SpecContext) {
+		hostStore, ok := dummyStore.(*host.Store[*Dummy])
+		Expect(ok).To(BeTrue())
+
+		created, err := hostStore.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "patched"}})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(dummyStore.Delete, context.Background(), "patched")
+
+		By("patching labels and annotations concurrently")
+		const patches = 20
+		var wg sync.WaitGroup
+		for i := range patches {
+			wg.Go(func() {
+				defer GinkgoRecover()
+				Expect(hostStore.Patch(ctx, "patched", func(obj *Dummy) error {
+					if obj.Labels == nil {
+						obj.Labels = map[string]string{}
+					}
+					obj.Labels[fmt.Sprint(i)] = "true"
+					return nil
+				})).To(Succeed())
+			})
+			wg.Go(func() {
+				defer GinkgoRecover()
+				Expect(hostStore.Patch(ctx, "patched", func(obj *Dummy) error {
+					if obj.Annotations == nil {
+						obj.Annotations = map[string]string{}
+					}
+					obj.Annotations[fmt.Sprint(i)] = "true"
+					return nil
+				})).To(Succeed())
+			})
+		}
+		wg.Wait()
+
+		patched, err := hostStore.Get(ctx, "patched")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(patched.Labels).To(HaveLen(patches))
+		Expect(patched.Annotations).To(HaveLen(patches))
+		Expect(patched.ResourceVersion).To(Equal(created.ResourceVersion + 2*patches))
+
+		By("aborting the patch if the mutation fails")
+		patchErr := errors.New("patch failed")
+		Expect(hostStore.Patch(ctx, "patched", func(obj *Dummy) error {
+			obj.Labels = nil
+			return patchErr
+		})).To(MatchError(patchErr))
+		unchanged, err := hostStore.Get(ctx, "patched")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unchanged.ResourceVersion).To(Equal(patched.ResourceVersion))
+	})
+
+	It("should restore a backup into an empty store", func(ctx SpecContext) {
+		encrypter, err := host.NewAESGCMEncrypter("key", map[string][]byte{"key": make([]byte, 32)})
+		Expect(err).NotTo(HaveOccurred())
+		newStore := func() *host.Store[*Dummy] {
+			s, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+				Dir: GinkgoT().TempDir(),
+				NewFunc: func() *Dummy {
+					return &Dummy{}
+				},
+				Encrypter: encrypter,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return s
+		}
+
+		By("populating and backing up a store")
+		source := newStore()
+		for _, id := range []string{"a", "b", "c"} {
+			_, err := source.Create(ctx, &Dummy{Metadata: api.Metadata{
+				ID:     id,
+				Labels: map[string]string{"id": id},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		var backup bytes.Buffer
+		Expect(source.Backup(ctx, &backup)).To(Succeed())
+
+		By("restoring into an empty store")
+		target := newStore()
+		watch, err := target.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(watch.Stop)
+		Expect(target.Restore(ctx, bytes.NewReader(backup.Bytes()))).To(Succeed())
+
+		By("asserting the restored objects")
+		objs, err := target.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(ConsistOf(
+			SatisfyAll(HaveField("ID", "a"), HaveField("Labels", map[string]string{"id": "a"})),
+			SatisfyAll(HaveField("ID", "b"), HaveField("Labels", map[string]string{"id": "b"})),
+			SatisfyAll(HaveField("ID", "c"), HaveField("Labels", map[string]string{"id": "c"})),
+		))
+
+		By("asserting the created events")
+		var events []store.WatchEvent[*Dummy]
+		for range 3 {
+			var event store.WatchEvent[*Dummy]
+			Eventually(watch.Events()).Should(Receive(&event))
+			events = append(events, event)
+		}
+		Expect(events).To(HaveEach(HaveField("Type", store.WatchEventTypeCreated)))
+
+		By("failing to restore over existing objects")
+		Expect(target.Restore(ctx, bytes.NewReader(backup.Bytes()))).To(MatchError(store.ErrAlreadyExists))
+	})
+
+	It("should require a typed deep copy to cache objects", func() {
+		_, err := host.NewStore[*plainDummy](host.Options[*plainDummy]{
+			Dir: GinkgoT().TempDir(),
+			NewFunc: func() *plainDummy {
+				return &plainDummy{}
+			},
+			Cache: true,
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should serve objects from the cache until another store writes them", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		encrypter := &countingEncrypter{}
+		cachedStore, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: dir,
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+			Encrypter: encrypter,
+			Cache:     true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("serving written objects without reading them")
+		_, err = cachedStore.Create(ctx, &Dummy{Metadata: api.Metadata{
+			ID:     "cached",
+			Labels: map[string]string{"version": "1"},
+		}})
+		Expect(err).NotTo(HaveOccurred())
+
+		obj, err := cachedStore.Get(ctx, "cached")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.Labels).To(Equal(map[string]string{"version": "1"}))
+		objs, err := cachedStore.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(1))
+		Expect(encrypter.decrypted).To(BeZero())
+
+		By("copying cached objects on the way out")
+		obj.Labels["version"] = "mutated"
+		obj, err = cachedStore.Get(ctx, "cached")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.Labels).To(Equal(map[string]string{"version": "1"}))
+
+		By("re-reading objects changed by another store")
+		other, err := host.NewStore[*Dummy](host.Options[*Dummy]{
+			Dir: dir,
+			NewFunc: func() *Dummy {
+				return &Dummy{}
+			},
+			Encrypter: encrypter,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		obj.Labels = map[string]string{"version": "2"}
+		_, err = other.Update(ctx, obj)
+		Expect(err).NotTo(HaveOccurred())
+		decrypted := encrypter.decrypted
+
+		obj, err = cachedStore.Get(ctx, "cached")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.Labels).To(Equal(map[string]string{"version": "2"}))
+		Expect(encrypter.decrypted).To(Equal(decrypted + 1))
+
+		_, err = cachedStore.Get(ctx, "cached")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encrypter.decrypted).To(Equal(decrypted + 1))
+
+		By("dropping objects deleted by another store")
+		Expect(other.Delete(ctx, "cached")).To(Succeed())
+		_, err = cachedStore.Get(ctx, "cached")
+		Expect(err).To(MatchError(store.ErrNotFound))
+	})
 })
+
+// countingEncrypter stores objects in plain text and counts the decrypted objects.
+type countingEncrypter struct {
+	decrypted int
+}
+
+func (e *countingEncrypter) Encrypt(plaintext []byte) ([]byte, error) {
+	return plaintext, nil
+}
+
+func (e *countingEncrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	e.decrypted++
+	return ciphertext, nil
+}
+
+type plainDummy struct {
+	api.Metadata `json:"metadata,omitempty"`
+}
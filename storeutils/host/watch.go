@@ -9,8 +9,9 @@ import (
 )
 
 type watch[E api.Object] struct {
-	store  *Store[E]
-	events chan store.WatchEvent[E]
+	store     *Store[E]
+	events    chan store.WatchEvent[E]
+	predicate func(E) bool
 }
 
 func (w *watch[E]) Stop() {
@@ -32,6 +32,23 @@ type Watch[E api.Object] interface {
 type WatchEvent[E api.Object] struct {
 	Type   WatchEventType
 	Object E
+	// Sequence is the store-wide sequence number of the write. It increases by one with every write
+	// of a store, so consumers watching all objects can detect missed events.
+	Sequence uint64
+}
+
+// WatchOptions configures a watch.
+type WatchOptions[E api.Object] struct {
+	// Predicate, if set, restricts the watch to events of objects it returns true for.
+	Predicate func(E) bool
+}
+
+// ListWatch is a watch that first delivers a Created event of every object existing when it started,
+// followed by the events of later writes.
+type ListWatch[E api.Object] interface {
+	Watch[E]
+	// Synced is closed once the events of all initial objects were delivered.
+	Synced() <-chan struct{}
 }
 
 type WatchEventType string
@@ -48,6 +65,10 @@ type Store[E api.Object] interface {
 	Update(ctx context.Context, obj E) (E, error)
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]E, error)
+	// Count returns the number of objects List would return without necessarily reading them.
+	Count(ctx context.Context) (int, error)
+	// Exists reports whether an object with the given id is stored without necessarily reading it.
+	Exists(ctx context.Context, id string) (bool, error)
 
 	Watch(ctx context.Context) (Watch[E], error)
 }
@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ironcore-dev/provider-utils/apiutils/api"
+	"github.com/ironcore-dev/provider-utils/storeutils/store"
+)
+
+// Dummy is the object type the conformance suite stores.
+type Dummy struct {
+	api.Metadata `json:"metadata,omitempty"`
+
+	Spec string `json:"spec,omitempty"`
+}
+
+func (d *Dummy) DeepCopy() *Dummy {
+	out := &Dummy{Spec: d.Spec}
+	d.Metadata.DeepCopyInto(&out.Metadata)
+	return out
+}
+
+// NewDummy returns an empty Dummy, suitable as a store's NewFunc.
+func NewDummy() *Dummy {
+	return &Dummy{}
+}
+
+const watchTimeout = 5 * time.Second
+
+// RunConformance validates the store.Store contract against stores created by newStore.
+// newStore must return a fresh, empty store on every call.
+func RunConformance(t *testing.T, newStore func() store.Store[*Dummy]) {
+	t.Helper()
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		created, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "obj"}, Spec: "a"})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.GetCreatedAt().IsZero() {
+			t.Fatal("expected Create to set createdAt")
+		}
+		if created.GetResourceVersion() == 0 {
+			t.Fatal("expected Create to set a resourceVersion")
+		}
+
+		got, err := s.Get(ctx, "obj")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got.Spec != "a" || got.GetResourceVersion() != created.GetResourceVersion() {
+			t.Fatalf("expected stored object to match created one, got %+v", got)
+		}
+
+		if _, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "obj"}}); !errors.Is(err, store.ErrAlreadyExists) {
+			t.Fatalf("expected %v on duplicate create, got %v", store.ErrAlreadyExists, err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		if _, err := s.Get(ctx, "missing"); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("expected %v on get, got %v", store.ErrNotFound, err)
+		}
+		if _, err := s.Update(ctx, &Dummy{Metadata: api.Metadata{ID: "missing"}}); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("expected %v on update, got %v", store.ErrNotFound, err)
+		}
+		if err := s.Delete(ctx, "missing"); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("expected %v on delete, got %v", store.ErrNotFound, err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		created, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "obj"}, Spec: "a"})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		createdVersion := created.GetResourceVersion()
+
+		obj, err := s.Get(ctx, "obj")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		obj.Spec = "b"
+		updated, err := s.Update(ctx, obj)
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if updated.GetResourceVersion() <= createdVersion {
+			t.Fatalf("expected resourceVersion to increase, got %d", updated.GetResourceVersion())
+		}
+
+		stale := &Dummy{Metadata: api.Metadata{ID: "obj", ResourceVersion: createdVersion}, Spec: "c"}
+		if _, err := s.Update(ctx, stale); !errors.Is(err, store.ErrResourceVersionNotLatest) {
+			t.Fatalf("expected %v on stale update, got %v", store.ErrResourceVersionNotLatest, err)
+		}
+
+		got, err := s.Get(ctx, "obj")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got.Spec != "b" {
+			t.Fatalf("expected spec %q, got %q", "b", got.Spec)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		if _, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "plain"}}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := s.Delete(ctx, "plain"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := s.Get(ctx, "plain"); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("expected %v after delete, got %v", store.ErrNotFound, err)
+		}
+
+		finalized := &Dummy{Metadata: api.Metadata{ID: "finalized", Finalizers: []string{"test"}}}
+		if _, err := s.Create(ctx, finalized); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := s.Delete(ctx, "finalized"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		got, err := s.Get(ctx, "finalized")
+		if err != nil {
+			t.Fatalf("expected object with finalizers to remain, got %v", err)
+		}
+		if got.GetDeletedAt() == nil {
+			t.Fatal("expected deletedAt to be set on object with finalizers")
+		}
+
+		got.SetFinalizers(nil)
+		if _, err := s.Update(ctx, got); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if _, err := s.Get(ctx, "finalized"); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("expected %v after removing finalizers, got %v", store.ErrNotFound, err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		objs, err := s.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(objs) != 0 {
+			t.Fatalf("expected empty store, got %d objects", len(objs))
+		}
+
+		for _, id := range []string{"a", "b", "c"} {
+			if _, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: id}}); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+		if err := s.Delete(ctx, "b"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+
+		objs, err = s.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		ids := map[string]bool{}
+		for _, obj := range objs {
+			ids[obj.GetID()] = true
+		}
+		if len(ids) != 2 || !ids["a"] || !ids["c"] {
+			t.Fatalf("expected objects a and c, got %v", ids)
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		assertCountMatchesList := func() {
+			t.Helper()
+			count, err := s.Count(ctx)
+			if err != nil {
+				t.Fatalf("Count: %v", err)
+			}
+			objs, err := s.List(ctx)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if count != len(objs) {
+				t.Fatalf("expected count %d to match listed objects %d", count, len(objs))
+			}
+		}
+
+		assertCountMatchesList()
+		for _, id := range []string{"a", "b", "c", "d"} {
+			if _, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: id}}); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			assertCountMatchesList()
+		}
+		for _, id := range []string{"b", "d"} {
+			if err := s.Delete(ctx, id); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			assertCountMatchesList()
+		}
+
+		count, err := s.Count(ctx)
+		if err != nil {
+			t.Fatalf("Count: %v", err)
+		}
+		if count != 2 {
+			t.Fatalf("expected count 2, got %d", count)
+		}
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		assertExists := func(id string, expected bool) {
+			t.Helper()
+			exists, err := s.Exists(ctx, id)
+			if err != nil {
+				t.Fatalf("Exists: %v", err)
+			}
+			if exists != expected {
+				t.Fatalf("expected exists of %q to be %t, got %t", id, expected, exists)
+			}
+		}
+
+		assertExists("obj", false)
+		if _, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "obj"}}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		assertExists("obj", true)
+		assertExists("never-created", false)
+
+		if err := s.Delete(ctx, "obj"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		assertExists("obj", false)
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		watch, err := s.Watch(ctx)
+		if err != nil {
+			t.Fatalf("Watch: %v", err)
+		}
+		defer watch.Stop()
+
+		created, err := s.Create(ctx, &Dummy{Metadata: api.Metadata{ID: "obj"}})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		expectWatchEvent(t, watch, store.WatchEventTypeCreated, "obj")
+
+		created.Spec = "b"
+		if _, err := s.Update(ctx, created); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		expectWatchEvent(t, watch, store.WatchEventTypeUpdated, "obj")
+
+		if err := s.Delete(ctx, "obj"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		expectWatchEvent(t, watch, store.WatchEventTypeDeleted, "obj")
+	})
+
+	t.Run("DeepCopy", func(t *testing.T) {
+		ctx := context.Background()
+		s := newStore()
+
+		if _, err := s.Create(ctx, &Dummy{
+			Metadata: api.Metadata{ID: "obj", Labels: map[string]string{"key": "value"}},
+			Spec:     "a",
+		}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		got, err := s.Get(ctx, "obj")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		got.Spec = "mutated"
+		got.Labels["key"] = "mutated"
+
+		again, err := s.Get(ctx, "obj")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if again.Spec != "a" || again.Labels["key"] != "value" {
+			t.Fatalf("expected mutation of a returned object to not affect the store, got %+v", again)
+		}
+	})
+}
+
+func expectWatchEvent(t *testing.T, watch store.Watch[*Dummy], eventType store.WatchEventType, id string) {
+	t.Helper()
+
+	select {
+	case evt := <-watch.Events():
+		if evt.Type != eventType {
+			t.Fatalf("expected watch event type %s, got %s", eventType, evt.Type)
+		}
+		if evt.Object.GetID() != id {
+			t.Fatalf("expected watch event for %q, got %q", id, evt.Object.GetID())
+		}
+	case <-time.After(watchTimeout):
+		t.Fatalf("timed out waiting for %s watch event", eventType)
+	}
+}